@@ -65,19 +65,7 @@ func Permutations(n, k int) Stream {
 //
 // Deprecated: Use TCombinations instead.
 func Combinations(n, k int) Stream {
-	if n < 0 {
-		panic("n must be greater than or equal to 0")
-	}
-	if k < 0 {
-		panic("k must be greater than or equal to 0")
-	}
-	result := &combinations{
-		values: make([]int, k),
-		n:      n,
-		k:      k,
-	}
-	result.Reset()
-	return result
+	return CombinationsInOrder(n, k, Lex)
 }
 
 // CombinationsWithReplacement is like Combinations except that
@@ -87,19 +75,7 @@ func Combinations(n, k int) Stream {
 //
 // Deprecated: Use TCombinationsWithReplacement instead.
 func CombinationsWithReplacement(n, k int) Stream {
-	if n < 0 {
-		panic("n must be greater than or equal to 0")
-	}
-	if k < 0 {
-		panic("k must be greater than or equal to 0")
-	}
-	result := &combinationsWithReplacement{
-		values: make([]int, k),
-		n:      n,
-		k:      k,
-	}
-	result.Reset()
-	return result
+	return CombinationsWithReplacementInOrder(n, k, Lex)
 }
 
 // OpsPosits yields all the possible positions of k binary operators in a
@@ -159,6 +135,7 @@ type combinations struct {
 	values []int
 	n      int
 	k      int
+	order  Order
 	done   bool
 }
 
@@ -184,29 +161,57 @@ func (c *combinations) Reset() {
 		return
 	}
 	for i := 0; i < c.k; i++ {
-		c.values[i] = i
+		if c.order.backward() {
+			c.values[i] = c.n - c.k + i
+		} else {
+			c.values[i] = i
+		}
+	}
+	if c.order.complemented() {
+		complement(c.values, c.n)
 	}
 }
 
 func (c *combinations) increment() {
+	c.done = !c.step(true)
+}
+
+// step moves values to the next tuple in c.order if forward is true or to
+// the previous tuple otherwise. If there is no such tuple, step returns
+// false and leaves values unchanged.
+func (c *combinations) step(forward bool) bool {
+	if c.order.complemented() {
+		complement(c.values, c.n)
+		defer complement(c.values, c.n)
+	}
+	if forward == c.order.backward() {
+		return c.decrement()
+	}
+	return c.incrementLex()
+}
+
+// incrementLex moves values to the next tuple in Lex order. If values is
+// the last tuple, incrementLex returns false and leaves values unchanged.
+func (c *combinations) incrementLex() bool {
 	idx := c.k - 1
 	for idx >= 0 && c.values[idx] == c.n-c.k+idx {
 		idx--
 	}
 	if idx < 0 {
-		c.done = true
-		return
+		return false
 	}
 	c.values[idx]++
 	for i := idx + 1; i < c.k; i++ {
 		c.values[i] = c.values[idx] + i - idx
 	}
+	return true
 }
 
 type combinationsWithReplacement struct {
 	values []int
 	n      int
 	k      int
+	order  Order
 	done   bool
 }
 
@@ -232,23 +237,50 @@ func (c *combinationsWithReplacement) Reset() {
 		return
 	}
 	for i := 0; i < c.k; i++ {
-		c.values[i] = 0
+		if c.order.backward() {
+			c.values[i] = c.n - 1
+		} else {
+			c.values[i] = 0
+		}
+	}
+	if c.order.complemented() {
+		complement(c.values, c.n)
 	}
 }
 
 func (c *combinationsWithReplacement) increment() {
+	c.done = !c.step(true)
+}
+
+// step moves values to the next tuple in c.order if forward is true or to
+// the previous tuple otherwise. If there is no such tuple, step returns
+// false and leaves values unchanged.
+func (c *combinationsWithReplacement) step(forward bool) bool {
+	if c.order.complemented() {
+		complement(c.values, c.n)
+		defer complement(c.values, c.n)
+	}
+	if forward == c.order.backward() {
+		return c.decrement()
+	}
+	return c.incrementLex()
+}
+
+// incrementLex moves values to the next tuple in Lex order. If values is
+// the last tuple, incrementLex returns false and leaves values unchanged.
+func (c *combinationsWithReplacement) incrementLex() bool {
 	idx := c.k - 1
 	for idx >= 0 && c.values[idx] == c.n-1 {
 		idx--
 	}
 	if idx < 0 {
-		c.done = true
-		return
+		return false
 	}
 	c.values[idx]++
 	for i := idx + 1; i < c.k; i++ {
 		c.values[i] = c.values[idx]
 	}
+	return true
 }
 
 type opsPosits struct {
@@ -302,6 +334,13 @@ func max(i, j int) int {
 	return j
 }
 
+func min(i, j int) int {
+	if i < j {
+		return i
+	}
+	return j
+}
+
 type permutations struct {
 	// Everything except the values preceding the value being changed.
 	// But if k = 0 unused is nil, the empty set.
@@ -0,0 +1,242 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Filter returns a Stream that yields only the tuples of stream for which
+// pred returns true. pred must not modify or retain the slice passed to
+// it. The returned Stream does not implement Counter. Filter takes
+// ownership of stream.
+func Filter(stream Stream, pred func(values []int) bool) Stream {
+	return &filteredStream{
+		stream: stream,
+		pred:   pred,
+		values: make([]int, stream.TupleSize()),
+	}
+}
+
+// Take returns a Stream that yields the first n tuples of stream or all
+// the tuples of stream if it has fewer than n. The returned Stream
+// implements Counter if stream does. Take takes ownership of stream. Take
+// panics if n is negative.
+func Take(stream Stream, n int) Stream {
+	checkTake(n)
+	result := &takenStream{stream: stream, n: n, remaining: n}
+	return withCount(
+		result, []Stream{stream}, func(counts []*big.Int) *big.Int {
+			limit := big.NewInt(int64(n))
+			if counts[0].Cmp(limit) > 0 {
+				return limit
+			}
+			return counts[0]
+		})
+}
+
+// Skip returns a Stream that yields the tuples of stream except for the
+// first n. The returned Stream implements Counter if stream does. Skip
+// takes ownership of stream. Skip panics if n is negative.
+func Skip(stream Stream, n int) Stream {
+	return Stride(stream, n, 1)
+}
+
+// Stride returns a Stream that yields every step-th tuple of stream
+// starting with the tuple at the zero based index offset. To divide the
+// work of going through stream among k workers round robin style, give
+// worker i Stride(stream, i, k). The returned Stream implements Counter
+// if stream does. Stride takes ownership of stream. Stride panics if
+// offset is negative or if step is less than 1.
+func Stride(stream Stream, offset, step int) Stream {
+	checkStride(offset, step)
+	result := &stridedStream{
+		stream: stream,
+		offset: offset,
+		step:   step,
+		values: make([]int, stream.TupleSize()),
+	}
+	return withCount(
+		result, []Stream{stream}, func(counts []*big.Int) *big.Int {
+
+			// The tuples at offset, offset+step, ... that are before the end
+			count := counts[0].Sub(counts[0], big.NewInt(int64(offset)))
+			if count.Sign() <= 0 {
+				return new(big.Int)
+			}
+			count.Sub(count, bigOne)
+			count.Quo(count, big.NewInt(int64(step)))
+			return count.Add(count, bigOne)
+		})
+}
+
+// Map returns a TStream that yields the tuples of stream with each value
+// x replaced by items[x]. Every value that stream yields must be a valid
+// index of items. Map takes ownership of stream.
+func Map[T any](stream Stream, items []T) *TStream[T] {
+	return newTStreamFrom(append([]T(nil), items...), stream)
+}
+
+// TMap returns a TStream that yields the tuples of stream with each item
+// x replaced by f(x). TMap calls f just once for each item that stream
+// picks from, not once for each tuple. TMap takes ownership of stream.
+func TMap[T, U any](stream *TStream[T], f func(x T) U) *TStream[U] {
+	if stream.stream == nil {
+		return &TStream[U]{}
+	}
+	items := make([]U, len(stream.items))
+	for i, item := range stream.items {
+		items[i] = f(item)
+	}
+	return newTStreamFrom(items, stream.stream)
+}
+
+// Filter returns a TStream that yields only the tuples of this TStream
+// for which pred returns true. pred must not modify or retain the slice
+// passed to it. Filter takes ownership of this TStream.
+func (t *TStream[T]) Filter(pred func(values []T) bool) *TStream[T] {
+	if t.stream == nil {
+		return &TStream[T]{}
+	}
+	items := t.items
+	tuple := make([]T, len(t.indexes))
+	return newTStreamFrom(items, Filter(t.stream, func(indexes []int) bool {
+		for i, index := range indexes {
+			tuple[i] = items[index]
+		}
+		return pred(tuple)
+	}))
+}
+
+// Take returns a TStream that yields the first n tuples of this TStream.
+// See the Take function for details. Take takes ownership of this
+// TStream.
+func (t *TStream[T]) Take(n int) *TStream[T] {
+	if t.stream == nil {
+		checkTake(n)
+		return &TStream[T]{}
+	}
+	return newTStreamFrom(t.items, Take(t.stream, n))
+}
+
+// Skip returns a TStream that yields the tuples of this TStream except
+// for the first n. See the Skip function for details. Skip takes
+// ownership of this TStream.
+func (t *TStream[T]) Skip(n int) *TStream[T] {
+	return t.Stride(n, 1)
+}
+
+// Stride returns a TStream that yields every step-th tuple of this
+// TStream starting with the tuple at offset. See the Stride function for
+// details. Stride takes ownership of this TStream.
+func (t *TStream[T]) Stride(offset, step int) *TStream[T] {
+	if t.stream == nil {
+		checkStride(offset, step)
+		return &TStream[T]{}
+	}
+	return newTStreamFrom(t.items, Stride(t.stream, offset, step))
+}
+
+func checkTake(n int) {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+}
+
+func checkStride(offset, step int) {
+	if offset < 0 {
+		panic("offset must be greater than or equal to 0")
+	}
+	if step < 1 {
+		panic("step must be at least 1")
+	}
+}
+
+type filteredStream struct {
+	stream Stream
+	pred   func(values []int) bool
+
+	// Holds tuples until we know whether to let them through
+	values []int
+}
+
+func (f *filteredStream) TupleSize() int {
+	return f.stream.TupleSize()
+}
+
+func (f *filteredStream) Next(values []int) bool {
+	if len(values) < len(f.values) {
+		panic(kSliceTooSmall)
+	}
+	for f.stream.Next(f.values) {
+		if f.pred(f.values) {
+			copy(values, f.values)
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filteredStream) Reset() {
+	f.stream.Reset()
+}
+
+type takenStream struct {
+	stream    Stream
+	n         int
+	remaining int
+}
+
+func (t *takenStream) TupleSize() int {
+	return t.stream.TupleSize()
+}
+
+func (t *takenStream) Next(values []int) bool {
+	if len(values) < t.stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if t.remaining == 0 || !t.stream.Next(values) {
+		return false
+	}
+	t.remaining--
+	return true
+}
+
+func (t *takenStream) Reset() {
+	t.stream.Reset()
+	t.remaining = t.n
+}
+
+type stridedStream struct {
+	stream  Stream
+	offset  int
+	step    int
+	started bool
+
+	// Receives the tuples we skip over
+	values []int
+}
+
+func (s *stridedStream) TupleSize() int {
+	return s.stream.TupleSize()
+}
+
+func (s *stridedStream) Next(values []int) bool {
+	if len(values) < len(s.values) {
+		panic(kSliceTooSmall)
+	}
+	skip := s.step - 1
+	if !s.started {
+		skip = s.offset
+		s.started = true
+	}
+	for i := 0; i < skip; i++ {
+		if !s.stream.Next(s.values) {
+			return false
+		}
+	}
+	return s.stream.Next(values)
+}
+
+func (s *stridedStream) Reset() {
+	s.stream.Reset()
+	s.started = false
+}
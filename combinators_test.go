@@ -0,0 +1,178 @@
+package gocombinatorics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Filter(
+		gocombinatorics.Product(3, 2),
+		func(values []int) bool { return values[0] != values[1] })
+	assert.Panics(func() { stream.Next(nil) })
+	assert.Equal(2, stream.TupleSize())
+	assertStream(t, stream, "0 1", "0 2", "1 0", "1 2", "2 0", "2 1")
+
+	// Next leaves values unchanged at the end.
+	allTuples(stream)
+	values := []int{7, 7}
+	assert.False(stream.Next(values))
+	assert.Equal([]int{7, 7}, values)
+
+	assertStream(t, gocombinatorics.Filter(
+		gocombinatorics.Product(3, 2),
+		func(values []int) bool { return false }))
+	_, ok := stream.(gocombinatorics.Counter)
+	assert.False(ok)
+}
+
+func TestTake(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Take(gocombinatorics.Combinations(4, 2), 4)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 1", "0 2", "0 3", "1 2")
+	assertStream(t,
+		gocombinatorics.Take(gocombinatorics.Combinations(4, 2), 10),
+		"0 1", "0 2", "0 3", "1 2", "1 3", "2 3")
+	assertStream(t, gocombinatorics.Take(gocombinatorics.Combinations(4, 2), 0))
+	assert.Panics(func() {
+		gocombinatorics.Take(gocombinatorics.Combinations(4, 2), -1)
+	})
+	for _, n := range []int{0, 4, 6, 10} {
+		assertCount(t, gocombinatorics.Take(gocombinatorics.Combinations(4, 2), n))
+	}
+	filtered := gocombinatorics.Filter(
+		gocombinatorics.Combinations(4, 2),
+		func(values []int) bool { return true })
+	_, ok := gocombinatorics.Take(filtered, 2).(gocombinatorics.Counter)
+	assert.False(ok)
+}
+
+func TestSkip(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), 4)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "1 3", "2 3")
+	assertStream(t, gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), 6))
+	assertStream(t, gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), 9))
+	assertStream(t,
+		gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), 0),
+		"0 1", "0 2", "0 3", "1 2", "1 3", "2 3")
+	assert.Panics(func() {
+		gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), -1)
+	})
+	for _, n := range []int{0, 4, 6, 9} {
+		assertCount(t, gocombinatorics.Skip(gocombinatorics.Combinations(4, 2), n))
+	}
+}
+
+func TestStride(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Stride(gocombinatorics.Product(2, 3), 1, 3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 0 1", "1 0 0", "1 1 1")
+
+	// Round robin shards together cover the whole stream.
+	var all []string
+	for i := 0; i < 3; i++ {
+		shard := gocombinatorics.Stride(gocombinatorics.Product(3, 2), i, 3)
+		all = append(all, allTuples(shard)...)
+	}
+	assert.ElementsMatch(allTuples(gocombinatorics.Product(3, 2)), all)
+
+	assertStream(t,
+		gocombinatorics.Stride(gocombinatorics.Product(2, 2), 5, 1))
+	assert.Panics(func() {
+		gocombinatorics.Stride(gocombinatorics.Product(2, 2), -1, 1)
+	})
+	assert.Panics(func() {
+		gocombinatorics.Stride(gocombinatorics.Product(2, 2), 0, 0)
+	})
+	for offset := 0; offset < 10; offset++ {
+		for step := 1; step < 10; step++ {
+			assertCount(t, gocombinatorics.Stride(
+				gocombinatorics.Product(2, 3), offset, step))
+		}
+	}
+}
+
+func TestCombinatorsCompose(t *testing.T) {
+	stream := gocombinatorics.Take(
+		gocombinatorics.Skip(
+			gocombinatorics.Filter(
+				gocombinatorics.Product(10, 2),
+				func(values []int) bool { return values[0] == values[1] }),
+			2),
+		3)
+	assertStream(t, stream, "2 2", "3 3", "4 4")
+}
+
+func TestMap(t *testing.T) {
+	assert := assert.New(t)
+	items := []string{"x", "y", "z"}
+	stream := gocombinatorics.Map(
+		gocombinatorics.Take(gocombinatorics.Permutations(3, 2), 3), items)
+
+	// Map copies items
+	items[0] = "w"
+	assertTStream(t, stream, "x y", "x z", "y x")
+	assert.Equal(2, stream.TupleSize())
+}
+
+func TestTMap(t *testing.T) {
+	calls := 0
+	stream := gocombinatorics.TMap(
+		gocombinatorics.TCombinations([]string{"a", "b", "c"}, 2),
+		func(x string) string {
+			calls++
+			return strings.ToUpper(x)
+		})
+	assertTStream(t, stream, "A B", "A C", "B C")
+	assert.Equal(t, 3, calls)
+	lengths := gocombinatorics.TMap(
+		gocombinatorics.TProduct([]string{"a", "bb"}, 2),
+		func(x string) int { return len(x) })
+	values := make([]int, 2)
+	var actual [][]int
+	for lengths.Next(values) {
+		actual = append(actual, append([]int(nil), values...))
+	}
+	assert.Equal(t, [][]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}}, actual)
+	var zero gocombinatorics.TStream[string]
+	assert.Equal(t, 0, gocombinatorics.TMap(
+		&zero, func(x string) int { return len(x) }).TupleSize())
+}
+
+func TestTStreamCombinators(t *testing.T) {
+	assert := assert.New(t)
+	items := []string{"a", "b", "c", "d"}
+	stream := gocombinatorics.TCombinations(items, 2).Filter(
+		func(values []string) bool { return values[0] != "b" })
+	assertTStream(t, stream, "a b", "a c", "a d", "c d")
+	assert.Nil(stream.Count())
+	_, ok := stream.Count64()
+	assert.False(ok)
+	assertTStream(t,
+		gocombinatorics.TCombinations(items, 2).Take(2), "a b", "a c")
+	assert.Equal(
+		"2", gocombinatorics.TCombinations(items, 2).Take(2).Count().String())
+	assert.Equal(
+		"3",
+		gocombinatorics.TCombinations(items, 2).Stride(1, 2).Count().String())
+	assertTStream(t,
+		gocombinatorics.TCombinations(items, 2).Skip(4), "b d", "c d")
+	assertTStream(t,
+		gocombinatorics.TCombinations(items, 2).Stride(1, 2),
+		"a c", "b c", "c d")
+	var zero gocombinatorics.TStream[string]
+	assertTStream(t, zero.Filter(func([]string) bool { return true }))
+	assertTStream(t, zero.Take(1))
+	assertTStream(t, zero.Skip(1))
+	assertTStream(t, zero.Stride(0, 2))
+	assert.Panics(func() { zero.Take(-1) })
+	assert.Panics(func() { zero.Stride(0, 0) })
+}
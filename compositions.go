@@ -0,0 +1,295 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// IntegerPartitions yields all the ways to write n as a sum of positive
+// ints where order does not matter. The returned Stream's Next method
+// yields n-tuples with the parts in non-increasing order followed by
+// enough zeros to fill out the tuple. Tuples come in lexicographic order.
+// The returned Stream implements Counter.
+//
+// For instance, IntegerPartitions(4) yields
+// (1,1,1,1), (2,1,1,0), (2,2,0,0), (3,1,0,0), (4,0,0,0)
+func IntegerPartitions(n int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	return newIntegerPartitions(n, n, 0)
+}
+
+// IntegerPartitionsWithParts yields all the ways to write n as a sum of
+// exactly k positive ints where order does not matter. The returned
+// Stream's Next method yields k-tuples with the parts in non-increasing
+// order. Tuples come in lexicographic order. The returned Stream
+// implements Counter.
+//
+// For instance, IntegerPartitionsWithParts(7, 3) yields
+// (3,2,2), (3,3,1), (4,2,1), (5,1,1)
+func IntegerPartitionsWithParts(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	return newIntegerPartitions(n, k, 1)
+}
+
+// Compositions yields all the ways to write n as a sum of exactly k
+// positive ints where order matters. The returned Stream's Next method
+// yields k-tuples in lexicographic order. The returned Stream implements
+// Counter.
+//
+// For instance, Compositions(5, 3) yields
+// (1,1,3), (1,2,2), (1,3,1), (2,1,2), (2,2,1), (3,1,1)
+func Compositions(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	return newCompositions(n, k, 1)
+}
+
+// WeakCompositions is like Compositions except that the parts may be
+// zero.
+//
+// For instance, WeakCompositions(2, 3) yields
+// (0,0,2), (0,1,1), (0,2,0), (1,0,1), (1,1,0), (2,0,0)
+func WeakCompositions(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	return newCompositions(n, k, 0)
+}
+
+func newIntegerPartitions(n, k, minPart int) Stream {
+	result := &integerPartitions{
+		values:  make([]int, k),
+		n:       n,
+		k:       k,
+		minPart: minPart,
+	}
+	result.Reset()
+	return result
+}
+
+// integerPartitions yields the non-increasing k-tuples summing to n with
+// each value at least minPart.
+type integerPartitions struct {
+	values  []int
+	n       int
+	k       int
+	minPart int
+	done    bool
+}
+
+func (p *integerPartitions) TupleSize() int {
+	return p.k
+}
+
+func (p *integerPartitions) Next(values []int) bool {
+	if len(values) < p.k {
+		panic(kSliceTooSmall)
+	}
+	if p.done {
+		return false
+	}
+	copy(values, p.values)
+	p.increment()
+	return true
+}
+
+func (p *integerPartitions) Reset() {
+	p.done = !canSum(p.n, p.k, p.minPart)
+	if p.done {
+		return
+	}
+	p.fill(0, p.n)
+}
+
+func (p *integerPartitions) Count() *big.Int {
+	if !canSum(p.n, p.k, p.minPart) {
+		return new(big.Int)
+	}
+
+	// Adding 1-minPart to each value gives a partition with exactly k
+	// positive parts.
+	return partitionsWithParts(p.n+p.k*(1-p.minPart), p.k)
+}
+
+func (p *integerPartitions) Count64() (int64, bool) {
+	return count64(p)
+}
+
+func (p *integerPartitions) increment() {
+	remaining := p.n
+	for i := 0; i < p.k-1; i++ {
+		remaining -= p.values[i]
+	}
+
+	// The last value is determined by the ones before it so start with
+	// the second to last value.
+	for idx := p.k - 2; idx >= 0; idx-- {
+		remaining += p.values[idx]
+		value := p.values[idx] + 1
+		if idx > 0 && value > p.values[idx-1] {
+			continue
+		}
+		if remaining-value < (p.k-1-idx)*p.minPart {
+			continue
+		}
+		p.values[idx] = value
+		p.fill(idx+1, remaining-value)
+		return
+	}
+	p.done = true
+}
+
+// fill fills in the positions from idx onward with the lexicographically
+// smallest values that sum to remaining.
+func (p *integerPartitions) fill(idx, remaining int) {
+	for ; idx < p.k; idx++ {
+		slots := p.k - idx
+		value := max((remaining+slots-1)/slots, p.minPart)
+		p.values[idx] = value
+		remaining -= value
+	}
+}
+
+func newCompositions(n, k, minPart int) Stream {
+	result := &compositions{
+		values:  make([]int, k),
+		n:       n,
+		k:       k,
+		minPart: minPart,
+	}
+	result.Reset()
+	return result
+}
+
+// compositions yields the k-tuples summing to n with each value at least
+// minPart.
+type compositions struct {
+	values  []int
+	n       int
+	k       int
+	minPart int
+	done    bool
+}
+
+func (c *compositions) TupleSize() int {
+	return c.k
+}
+
+func (c *compositions) Next(values []int) bool {
+	if len(values) < c.k {
+		panic(kSliceTooSmall)
+	}
+	if c.done {
+		return false
+	}
+	copy(values, c.values)
+	c.increment()
+	return true
+}
+
+func (c *compositions) Reset() {
+	c.done = !canSum(c.n, c.k, c.minPart)
+	if c.done {
+		return
+	}
+	c.fill(0, c.n)
+}
+
+func (c *compositions) Count() *big.Int {
+	if !canSum(c.n, c.k, c.minPart) {
+		return new(big.Int)
+	}
+	if c.k == 0 {
+		return big.NewInt(1)
+	}
+
+	// Adding 1-minPart to each value gives a composition with positive
+	// parts.
+	return binomial(c.n+c.k*(1-c.minPart)-1, c.k-1)
+}
+
+func (c *compositions) Count64() (int64, bool) {
+	return count64(c)
+}
+
+func (c *compositions) increment() {
+	if c.k == 0 {
+		c.done = true
+		return
+	}
+
+	// The last value is determined by the ones before it. Increasing the
+	// value at idx means taking one from the values after idx.
+	remaining := c.values[c.k-1]
+	for idx := c.k - 2; idx >= 0; idx-- {
+		if remaining > (c.k-1-idx)*c.minPart {
+			c.values[idx]++
+			c.fill(idx+1, remaining-1)
+			return
+		}
+		remaining += c.values[idx]
+	}
+	c.done = true
+}
+
+// fill fills in the positions from idx onward with the lexicographically
+// smallest values that sum to remaining.
+func (c *compositions) fill(idx, remaining int) {
+	if c.k == 0 {
+		return
+	}
+	for ; idx < c.k-1; idx++ {
+		c.values[idx] = c.minPart
+		remaining -= c.minPart
+	}
+	c.values[c.k-1] = remaining
+}
+
+// canSum returns true if n can be written as a sum of k ints each of
+// which is at least minPart.
+func canSum(n, k, minPart int) bool {
+	if k == 0 {
+		return n == 0
+	}
+	return n >= k*minPart
+}
+
+// partitionsWithParts returns the number of ways to write n as a sum of
+// exactly k positive ints where order does not matter.
+func partitionsWithParts(n, k int) *big.Int {
+
+	// ways[i][j] is the number of ways to write i as a sum of exactly j
+	// positive ints. Either one of the parts is 1, or subtracting 1 from
+	// every part gives a partition of i-j into j parts.
+	ways := make([][]*big.Int, n+1)
+	for i := range ways {
+		ways[i] = make([]*big.Int, k+1)
+		for j := range ways[i] {
+			ways[i][j] = new(big.Int)
+			switch {
+			case i == 0 && j == 0:
+				ways[i][j].SetInt64(1)
+			case i == 0 || j == 0:
+			default:
+				ways[i][j].Add(ways[i][j], ways[i-1][j-1])
+				if i >= j {
+					ways[i][j].Add(ways[i][j], ways[i-j][j])
+				}
+			}
+		}
+	}
+	return ways[n][k]
+}
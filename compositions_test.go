@@ -0,0 +1,130 @@
+package gocombinatorics_test
+
+import (
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegerPartitions(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.IntegerPartitions(4)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"1 1 1 1", "2 1 1 0", "2 2 0 0", "3 1 0 0", "4 0 0 0")
+	assertStream(t, gocombinatorics.IntegerPartitions(1), "1")
+	assertStream(t, gocombinatorics.IntegerPartitions(0), "")
+	assert.Panics(func() { gocombinatorics.IntegerPartitions(-1) })
+	expected := []string{"1", "1", "2", "3", "5", "7", "11", "15", "22"}
+	for n := range expected {
+		counter := gocombinatorics.IntegerPartitions(n).(gocombinatorics.Counter)
+		assert.Equal(expected[n], counter.Count().String())
+	}
+	counter := gocombinatorics.IntegerPartitions(100).(gocombinatorics.Counter)
+	assert.Equal("190569292", counter.Count().String())
+}
+
+func TestIntegerPartitionsWithParts(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.IntegerPartitionsWithParts(7, 3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "3 2 2", "3 3 1", "4 2 1", "5 1 1")
+	assertStream(t, gocombinatorics.IntegerPartitionsWithParts(3, 3), "1 1 1")
+	assertStream(t, gocombinatorics.IntegerPartitionsWithParts(3, 4))
+	assertStream(t, gocombinatorics.IntegerPartitionsWithParts(3, 1), "3")
+	assertStream(t, gocombinatorics.IntegerPartitionsWithParts(3, 0))
+	assertStream(t, gocombinatorics.IntegerPartitionsWithParts(0, 0), "")
+	assert.Panics(func() { gocombinatorics.IntegerPartitionsWithParts(-1, 1) })
+	assert.Panics(func() { gocombinatorics.IntegerPartitionsWithParts(1, -1) })
+}
+
+func TestCompositions(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Compositions(5, 3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"1 1 3", "1 2 2", "1 3 1", "2 1 2", "2 2 1", "3 1 1")
+	assertStream(t, gocombinatorics.Compositions(3, 3), "1 1 1")
+	assertStream(t, gocombinatorics.Compositions(3, 4))
+	assertStream(t, gocombinatorics.Compositions(3, 1), "3")
+	assertStream(t, gocombinatorics.Compositions(3, 0))
+	assertStream(t, gocombinatorics.Compositions(0, 0), "")
+	assert.Panics(func() { gocombinatorics.Compositions(-1, 1) })
+	assert.Panics(func() { gocombinatorics.Compositions(1, -1) })
+}
+
+func TestWeakCompositions(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.WeakCompositions(2, 3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"0 0 2", "0 1 1", "0 2 0", "1 0 1", "1 1 0", "2 0 0")
+	assertStream(t, gocombinatorics.WeakCompositions(0, 2), "0 0")
+	assertStream(t, gocombinatorics.WeakCompositions(2, 1), "2")
+	assertStream(t, gocombinatorics.WeakCompositions(2, 0))
+	assertStream(t, gocombinatorics.WeakCompositions(0, 0), "")
+	assert.Panics(func() { gocombinatorics.WeakCompositions(-1, 1) })
+	assert.Panics(func() { gocombinatorics.WeakCompositions(1, -1) })
+}
+
+func TestPartitionsAndCompositionsMatchProduct(t *testing.T) {
+	for n := 0; n < 7; n++ {
+		for k := 0; k < 7; k++ {
+			partitions, positive, weak := []string{}, []string{}, []string{}
+			stream := gocombinatorics.Product(n+1, k)
+			values := make([]int, k)
+			for stream.Next(values) {
+				if sumOf(values) != n {
+					continue
+				}
+				weak = append(weak, asString(values))
+				if minOf(values) > 0 {
+					positive = append(positive, asString(values))
+					if nonIncreasing(values) {
+						partitions = append(partitions, asString(values))
+					}
+				}
+			}
+			assertTuplesAndCount(
+				t, gocombinatorics.IntegerPartitionsWithParts(n, k), partitions)
+			assertTuplesAndCount(t, gocombinatorics.Compositions(n, k), positive)
+			assertTuplesAndCount(t, gocombinatorics.WeakCompositions(n, k), weak)
+		}
+		assertCount(t, gocombinatorics.IntegerPartitions(n))
+	}
+}
+
+func assertTuplesAndCount(
+	t *testing.T, stream gocombinatorics.Stream, expected []string) {
+	t.Helper()
+	assert.Equal(t, expected, allTuples(stream))
+	assertCount(t, stream)
+}
+
+func sumOf(values []int) int {
+	result := 0
+	for _, value := range values {
+		result += value
+	}
+	return result
+}
+
+func minOf(values []int) int {
+	result := 1
+	for _, value := range values {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+func nonIncreasing(values []int) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			return false
+		}
+	}
+	return true
+}
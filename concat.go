@@ -0,0 +1,342 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Concat returns a Stream that yields all the tuples of the first stream,
+// then all the tuples of the second stream, and so on. Reset on the
+// returned Stream resets all the streams. The returned Stream implements
+// Counter if all the streams do. Concat takes ownership of the streams.
+// Concat panics if the streams do not all have the same tuple size. Use
+// ConcatVar to chain streams with different tuple sizes.
+func Concat(streams ...Stream) Stream {
+	streams = append([]Stream(nil), streams...)
+	result := &concatStream{
+		streams:   streams,
+		tupleSize: commonTupleSize(streams),
+	}
+	return withCount(result, streams, sumCounts)
+}
+
+// ConcatVar works like Concat except that it chains VarStreams which may
+// have different maximum tuple sizes. For instance, to go through all the
+// 2-subsets of n things followed by all the 3-subsets use
+//
+//	ConcatVar(
+//		AsVarStream(Combinations(n, 2)),
+//		AsVarStream(Combinations(n, 3)))
+func ConcatVar(streams ...VarStream) VarStream {
+	maxTupleSize := 0
+	for _, stream := range streams {
+		maxTupleSize = max(maxTupleSize, stream.MaxTupleSize())
+	}
+	return &concatVarStream{
+		streams:      append([]VarStream(nil), streams...),
+		maxTupleSize: maxTupleSize,
+	}
+}
+
+// Zip returns a Stream whose tuples are the tuples of the streams laid
+// side by side. That is, the nth tuple of the returned Stream is the nth
+// tuple of the first stream followed by the nth tuple of the second
+// stream and so on. The tuple size of the returned Stream is the sum of
+// the tuple sizes of the streams. The returned Stream ends as soon as
+// any of the streams ends, and it yields nothing if there are no streams.
+// Reset on the returned Stream resets all the streams. The returned Stream
+// implements Counter if all the streams do. Zip takes ownership of the
+// streams.
+func Zip(streams ...Stream) Stream {
+	streams = append([]Stream(nil), streams...)
+	tupleSize := 0
+	for _, stream := range streams {
+		tupleSize += stream.TupleSize()
+	}
+	result := &zipStream{
+		streams: streams,
+		values:  make([]int, tupleSize),
+	}
+	return withCount(result, streams, func(counts []*big.Int) *big.Int {
+		if len(counts) == 0 {
+			return new(big.Int)
+		}
+		result := counts[0]
+		for _, count := range counts[1:] {
+			if count.Cmp(result) < 0 {
+				result = count
+			}
+		}
+		return result
+	})
+}
+
+// Interleave returns a Stream that yields the first tuple of each stream,
+// then the second tuple of each stream, and so on. Once a stream runs
+// out of tuples, Interleave skips it. Reset on the returned Stream resets
+// all the streams. The returned Stream implements Counter if all the
+// streams do. Interleave takes ownership of the streams. Interleave panics
+// if the streams do not all have the same tuple size.
+func Interleave(streams ...Stream) Stream {
+	streams = append([]Stream(nil), streams...)
+	result := &interleavedStream{
+		streams:   streams,
+		exhausted: make([]bool, len(streams)),
+		tupleSize: commonTupleSize(streams),
+	}
+	return withCount(result, streams, sumCounts)
+}
+
+// TConcat works like Concat but for TStreams. Zero value TStreams
+// contribute nothing. TConcat takes ownership of the streams.
+func TConcat[T any](streams ...*TStream[T]) *TStream[T] {
+	items, indexStreams, _ := combineTStreams(streams)
+	return newTStreamFrom(items, Concat(indexStreams...))
+}
+
+// TZip works like Zip but for TStreams. If any of the streams is a zero
+// value TStream, the returned TStream yields nothing. TZip takes
+// ownership of the streams.
+func TZip[T any](streams ...*TStream[T]) *TStream[T] {
+	items, indexStreams, hasZero := combineTStreams(streams)
+	if hasZero {
+		indexStreams = append(indexStreams, emptyStream{})
+	}
+	return newTStreamFrom(items, Zip(indexStreams...))
+}
+
+// TInterleave works like Interleave but for TStreams. Zero value TStreams
+// contribute nothing. TInterleave takes ownership of the streams.
+func TInterleave[T any](streams ...*TStream[T]) *TStream[T] {
+	items, indexStreams, _ := combineTStreams(streams)
+	return newTStreamFrom(items, Interleave(indexStreams...))
+}
+
+// combineTStreams returns the items of all the streams together along
+// with Streams of indexes into those items that yield the same tuples as
+// the streams. combineTStreams leaves out zero value TStreams since they
+// have no tuple size and reports whether there were any.
+func combineTStreams[T any](
+	streams []*TStream[T]) (items []T, indexStreams []Stream, hasZero bool) {
+	for _, stream := range streams {
+		if stream.stream == nil {
+			hasZero = true
+			continue
+		}
+		indexStreams = append(indexStreams, withCount(
+			&shiftedStream{stream: stream.stream, offset: len(items)},
+			[]Stream{stream.stream},
+			sumCounts))
+		items = append(items, stream.items...)
+	}
+	return items, indexStreams, hasZero
+}
+
+// sumCounts returns the sum of counts.
+func sumCounts(counts []*big.Int) *big.Int {
+	result := new(big.Int)
+	for _, count := range counts {
+		result.Add(result, count)
+	}
+	return result
+}
+
+// commonTupleSize returns the tuple size that all the streams share. If
+// streams is empty, commonTupleSize returns 0. commonTupleSize panics if
+// the streams have different tuple sizes.
+func commonTupleSize(streams []Stream) int {
+	if len(streams) == 0 {
+		return 0
+	}
+	result := streams[0].TupleSize()
+	for _, stream := range streams[1:] {
+		if stream.TupleSize() != result {
+			panic("streams must all have the same tuple size")
+		}
+	}
+	return result
+}
+
+type concatStream struct {
+	streams   []Stream
+	tupleSize int
+
+	// The index of the stream we are reading from
+	current int
+}
+
+func (c *concatStream) TupleSize() int {
+	return c.tupleSize
+}
+
+func (c *concatStream) Next(values []int) bool {
+	if len(values) < c.tupleSize {
+		panic(kSliceTooSmall)
+	}
+	for ; c.current < len(c.streams); c.current++ {
+		if c.streams[c.current].Next(values) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *concatStream) Reset() {
+	for _, stream := range c.streams {
+		stream.Reset()
+	}
+	c.current = 0
+}
+
+type concatVarStream struct {
+	streams      []VarStream
+	maxTupleSize int
+
+	// The index of the stream we are reading from
+	current int
+}
+
+func (c *concatVarStream) MaxTupleSize() int {
+	return c.maxTupleSize
+}
+
+func (c *concatVarStream) NextN(values []int) (int, bool) {
+	if len(values) < c.maxTupleSize {
+		panic(kSliceTooSmall)
+	}
+	for ; c.current < len(c.streams); c.current++ {
+		if n, ok := c.streams[c.current].NextN(values); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func (c *concatVarStream) Reset() {
+	for _, stream := range c.streams {
+		stream.Reset()
+	}
+	c.current = 0
+}
+
+type zipStream struct {
+	streams []Stream
+
+	// Holds the combined tuple until we know every stream has a tuple
+	values []int
+}
+
+func (z *zipStream) TupleSize() int {
+	return len(z.values)
+}
+
+func (z *zipStream) Next(values []int) bool {
+	if len(values) < len(z.values) {
+		panic(kSliceTooSmall)
+	}
+	if len(z.streams) == 0 {
+		return false
+	}
+	start := 0
+	for _, stream := range z.streams {
+		end := start + stream.TupleSize()
+		if !stream.Next(z.values[start:end]) {
+			return false
+		}
+		start = end
+	}
+	copy(values, z.values)
+	return true
+}
+
+func (z *zipStream) Reset() {
+	for _, stream := range z.streams {
+		stream.Reset()
+	}
+}
+
+type interleavedStream struct {
+	streams   []Stream
+	exhausted []bool
+	tupleSize int
+
+	// The index of the stream to read from next
+	current int
+}
+
+func (s *interleavedStream) TupleSize() int {
+	return s.tupleSize
+}
+
+func (s *interleavedStream) Next(values []int) bool {
+	if len(values) < s.tupleSize {
+		panic(kSliceTooSmall)
+	}
+
+	// Try each stream at most once starting with the current one.
+	for range s.streams {
+		idx := s.current
+		s.current = (s.current + 1) % len(s.streams)
+		if s.exhausted[idx] {
+			continue
+		}
+		if s.streams[idx].Next(values) {
+			return true
+		}
+		s.exhausted[idx] = true
+	}
+	return false
+}
+
+func (s *interleavedStream) Reset() {
+	for i, stream := range s.streams {
+		stream.Reset()
+		s.exhausted[i] = false
+	}
+	s.current = 0
+}
+
+// shiftedStream adds offset to every value that stream yields.
+type shiftedStream struct {
+	stream Stream
+	offset int
+}
+
+func (s *shiftedStream) TupleSize() int {
+	return s.stream.TupleSize()
+}
+
+func (s *shiftedStream) Next(values []int) bool {
+	if !s.stream.Next(values) {
+		return false
+	}
+	for i := 0; i < s.stream.TupleSize(); i++ {
+		values[i] += s.offset
+	}
+	return true
+}
+
+func (s *shiftedStream) Reset() {
+	s.stream.Reset()
+}
+
+// emptyStream yields no tuples.
+type emptyStream struct{}
+
+func (emptyStream) TupleSize() int {
+	return 0
+}
+
+func (emptyStream) Next(values []int) bool {
+	return false
+}
+
+func (emptyStream) Reset() {
+}
+
+func (emptyStream) Count() *big.Int {
+	return new(big.Int)
+}
+
+func (emptyStream) Count64() (int64, bool) {
+	return 0, true
+}
@@ -0,0 +1,130 @@
+package gocombinatorics_test
+
+import (
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcat(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Concat(
+		gocombinatorics.Combinations(3, 2),
+		gocombinatorics.Combinations(1, 2),
+		gocombinatorics.Product(2, 2))
+	assert.Panics(func() { stream.Next(nil) })
+	assert.Equal(2, stream.TupleSize())
+	assertStream(t, stream,
+		"0 1", "0 2", "1 2", "0 0", "0 1", "1 0", "1 1")
+	assertCount(t, stream)
+	assertStream(t, gocombinatorics.Concat())
+	assertCount(t, gocombinatorics.Concat())
+	_, ok := gocombinatorics.Concat(
+		gocombinatorics.Combinations(3, 2),
+		gocombinatorics.Filter(
+			gocombinatorics.Product(2, 2),
+			func(values []int) bool { return true })).(gocombinatorics.Counter)
+	assert.False(ok)
+	assert.Panics(func() {
+		gocombinatorics.Concat(
+			gocombinatorics.Combinations(3, 2),
+			gocombinatorics.Combinations(3, 1))
+	})
+}
+
+func TestConcatVar(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.ConcatVar(
+		gocombinatorics.AsVarStream(gocombinatorics.Combinations(3, 2)),
+		gocombinatorics.AsVarStream(gocombinatorics.Combinations(3, 3)),
+		gocombinatorics.PowerSet(1))
+	assert.Panics(func() { stream.NextN(make([]int, 2)) })
+	assert.Equal(3, stream.MaxTupleSize())
+	assertVarStream(t, stream, "0 1", "0 2", "1 2", "0 1 2", "", "0")
+	assertVarStream(t, gocombinatorics.ConcatVar())
+}
+
+func TestZip(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Zip(
+		gocombinatorics.Cartesian(2, 2),
+		gocombinatorics.Product(10, 1))
+	assert.Panics(func() { stream.Next(make([]int, 2)) })
+	assert.Equal(3, stream.TupleSize())
+	assertStream(t, stream, "0 0 0", "0 1 1", "1 0 2", "1 1 3")
+	assertCount(t, stream)
+
+	// Next leaves values unchanged at the end.
+	stream = gocombinatorics.Zip(
+		gocombinatorics.Product(10, 1),
+		gocombinatorics.Combinations(3, 2))
+	allTuples(stream)
+	values := []int{7, 7, 7}
+	assert.False(stream.Next(values))
+	assert.Equal([]int{7, 7, 7}, values)
+	stream.Reset()
+	assertNext(t, stream, "0 0 1", "1 0 2", "2 1 2")
+
+	assertCount(t, stream)
+	assertStream(t, gocombinatorics.Zip())
+	assertCount(t, gocombinatorics.Zip())
+}
+
+func TestInterleave(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Interleave(
+		gocombinatorics.Product(3, 1),
+		gocombinatorics.Combinations(1, 1),
+		gocombinatorics.Combinations(2, 1))
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0", "0", "0", "1", "1", "2")
+	assertCount(t, stream)
+
+	// Like Concat and Zip, Interleave continues from where each stream is.
+	partial := gocombinatorics.Product(3, 1)
+	assertNext(t, partial, "0")
+	stream = gocombinatorics.Interleave(
+		partial, gocombinatorics.Combinations(2, 1))
+	assertNext(t, stream, "1", "0", "2", "1")
+	assert.False(stream.Next(make([]int, 1)))
+	assertStream(t, gocombinatorics.Interleave())
+	assert.Panics(func() {
+		gocombinatorics.Interleave(
+			gocombinatorics.Product(3, 1), gocombinatorics.Product(3, 2))
+	})
+}
+
+func TestTConcatZipInterleave(t *testing.T) {
+	numbers := gocombinatorics.TProduct([]string{"1", "2"}, 1)
+	letters := gocombinatorics.TCombinations([]string{"a", "b", "c"}, 1)
+	assertTStream(t,
+		gocombinatorics.TConcat(numbers, letters), "1", "2", "a", "b", "c")
+	numbers = gocombinatorics.TProduct([]string{"1", "2"}, 1)
+	letters = gocombinatorics.TCombinations([]string{"a", "b", "c"}, 1)
+	assertTStream(t,
+		gocombinatorics.TZip(letters, numbers), "a 1", "b 2")
+	numbers = gocombinatorics.TProduct([]string{"1", "2"}, 1)
+	letters = gocombinatorics.TCombinations([]string{"a", "b", "c"}, 1)
+	stream := gocombinatorics.TInterleave(letters, numbers)
+	assertTStream(t, stream, "a", "1", "b", "2", "c")
+	assert.Equal(t, "5", stream.Count().String())
+	var zero gocombinatorics.TStream[string]
+	assertTStream(t, gocombinatorics.TZip(
+		gocombinatorics.TProduct([]string{"1", "2"}, 0), &zero))
+	zipped := gocombinatorics.TZip(
+		gocombinatorics.TCombinations([]string{"a", "b", "c"}, 2), &zero)
+	assertTStream(t, zipped)
+	assert.Equal(t, "0", zipped.Count().String())
+	stream = gocombinatorics.TConcat(
+		gocombinatorics.TCombinations([]string{"a", "b", "c"}, 2), &zero)
+	assertTStream(t, stream, "a b", "a c", "b c")
+	assert.Equal(t, "3", stream.Count().String())
+	stream = gocombinatorics.TInterleave(
+		&zero,
+		gocombinatorics.TCombinations([]string{"a", "b"}, 2),
+		gocombinatorics.TProduct([]string{"x"}, 2))
+	assertTStream(t, stream, "a b", "x x")
+	assertTStream(t, gocombinatorics.TConcat(&zero, &zero))
+	assertTStream(t, gocombinatorics.TConcat[string]())
+}
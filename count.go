@@ -0,0 +1,209 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Counter is implemented by streams that know how many tuples they emit
+// without having to iterate over them. Most of the streams in this package
+// implement Counter. Streams from Filter and FlatMap do not. Streams from
+// Take, Skip, Stride, Concat, Zip, Interleave, Pad, AsVarStream, and
+// TrimTrailing implement Counter only if the streams they come from do.
+//
+// TStream and TVarStream always implement Counter, but their Count method
+// returns nil when the stream underneath cannot count its tuples, as with
+// a TStream from Filter or TFlatMap. Code that accepts a Counter that may
+// be a TStream or TVarStream must check for nil.
+type Counter interface {
+
+	// Count returns the total number of tuples the stream emits from
+	// start to finish. Count is unaffected by calls to Next and Reset.
+	// Caller is free to modify the returned value. Count returns nil only
+	// for a TStream or TVarStream that cannot count its tuples.
+	Count() *big.Int
+
+	// Count64 is like Count except that it returns the count as an int64.
+	// If the count does not fit in an int64 or if Count returns nil,
+	// Count64 returns false.
+	Count64() (int64, bool)
+}
+
+func (c *combinations) Count() *big.Int {
+	if c.k > c.n {
+		return new(big.Int)
+	}
+	return binomial(c.n, c.k)
+}
+
+func (c *combinations) Count64() (int64, bool) {
+	return count64(c)
+}
+
+func (c *combinationsWithReplacement) Count() *big.Int {
+	return multichoose(c.n, c.k)
+}
+
+func (c *combinationsWithReplacement) Count64() (int64, bool) {
+	return count64(c)
+}
+
+// Count returns the kth Catalan number.
+func (o *opsPosits) Count() *big.Int {
+	result := binomial(2*o.k, o.k)
+	return result.Quo(result, big.NewInt(int64(o.k+1)))
+}
+
+func (o *opsPosits) Count64() (int64, bool) {
+	return count64(o)
+}
+
+func (p *permutations) Count() *big.Int {
+	if p.k > p.n {
+		return new(big.Int)
+	}
+	return new(big.Int).MulRange(int64(p.n-p.k+1), int64(p.n))
+}
+
+func (p *permutations) Count64() (int64, bool) {
+	return count64(p)
+}
+
+func (c *cartesian) Count() *big.Int {
+	result := big.NewInt(1)
+	for _, size := range c.sizes {
+		result.Mul(result, big.NewInt(int64(size)))
+	}
+	return result
+}
+
+func (c *cartesian) Count64() (int64, bool) {
+	return count64(c)
+}
+
+func (p *product) Count() *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(p.n)), big.NewInt(int64(p.k)), nil)
+}
+
+func (p *product) Count64() (int64, bool) {
+	return count64(p)
+}
+
+// Count returns the total number of tuples this TStream emits from start
+// to finish. Count is unaffected by calls to Next and Reset. If this
+// TStream cannot count its tuples without going through them, as with a
+// TStream from Filter, Count returns nil.
+func (t *TStream[T]) Count() *big.Int {
+	if t.stream == nil {
+		return new(big.Int)
+	}
+	counter, ok := t.stream.(Counter)
+	if !ok {
+		return nil
+	}
+	return counter.Count()
+}
+
+// Count64 is like Count except that it returns the count as an int64.
+// If the count does not fit in an int64 or if Count returns nil, Count64
+// returns false.
+func (t *TStream[T]) Count64() (int64, bool) {
+	return count64(t)
+}
+
+// binomial returns n choose k. binomial returns 0 if k < 0 or k > n.
+func binomial(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// multichoose returns the number of ways to choose k things from n things
+// with replacement where order does not matter.
+func multichoose(n, k int) *big.Int {
+	if k == 0 {
+		return big.NewInt(1)
+	}
+	return binomial(n+k-1, k)
+}
+
+func count64(c interface{ Count() *big.Int }) (int64, bool) {
+	count := c.Count()
+	if count == nil || !count.IsInt64() {
+		return 0, false
+	}
+	return count.Int64(), true
+}
+
+// withCount returns stream with a Count method added if all the sources
+// implement Counter. Count on the returned Stream applies count to the
+// counts of the sources. If any of the sources does not implement
+// Counter, withCount returns stream unchanged.
+func withCount[S any](
+	stream Stream,
+	sources []S,
+	count func(counts []*big.Int) *big.Int) Stream {
+	c, ok := newCounted(sources, count)
+	if !ok {
+		return stream
+	}
+	return &countedStream{Stream: stream, counted: c}
+}
+
+// withVarCount works like withCount but for VarStreams.
+func withVarCount[S any](
+	stream VarStream,
+	sources []S,
+	count func(counts []*big.Int) *big.Int) VarStream {
+	c, ok := newCounted(sources, count)
+	if !ok {
+		return stream
+	}
+	return &countedVarStream{VarStream: stream, counted: c}
+}
+
+// counted computes a count from the counts of other streams.
+type counted struct {
+	counters []Counter
+	count    func(counts []*big.Int) *big.Int
+}
+
+// newCounted returns a counted that applies count to the counts of the
+// sources and true. If any of the sources does not implement Counter,
+// newCounted returns nil and false.
+func newCounted[S any](
+	sources []S, count func(counts []*big.Int) *big.Int) (*counted, bool) {
+	counters := make([]Counter, len(sources))
+	for i, source := range sources {
+		counter, ok := any(source).(Counter)
+		if !ok {
+			return nil, false
+		}
+		counters[i] = counter
+	}
+	return &counted{counters: counters, count: count}, true
+}
+
+func (c *counted) Count() *big.Int {
+	counts := make([]*big.Int, len(c.counters))
+	for i, counter := range c.counters {
+		counts[i] = counter.Count()
+	}
+	return c.count(counts)
+}
+
+func (c *counted) Count64() (int64, bool) {
+	return count64(c)
+}
+
+// countedStream is a Stream with a Count method added.
+type countedStream struct {
+	Stream
+	*counted
+}
+
+// countedVarStream is a VarStream with a Count method added.
+type countedVarStream struct {
+	VarStream
+	*counted
+}
@@ -0,0 +1,75 @@
+package gocombinatorics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	for n := 0; n < 7; n++ {
+		for k := 0; k < 7; k++ {
+			assertCount(t, gocombinatorics.Combinations(n, k))
+			assertCount(t, gocombinatorics.CombinationsWithReplacement(n, k))
+			assertCount(t, gocombinatorics.Permutations(n, k))
+			assertCount(t, gocombinatorics.Product(n, k))
+			assertCount(t, gocombinatorics.Cartesian(n, k, 3))
+		}
+		assertCount(t, gocombinatorics.OpsPosits(n))
+	}
+	assertCount(t, gocombinatorics.Cartesian())
+}
+
+func TestCountLarge(t *testing.T) {
+	assert := assert.New(t)
+	counter := gocombinatorics.Permutations(30, 30).(gocombinatorics.Counter)
+	assert.Equal(
+		"265252859812191058636308480000000", counter.Count().String())
+	_, ok := counter.Count64()
+	assert.False(ok)
+	counter = gocombinatorics.Combinations(60, 8).(gocombinatorics.Counter)
+	count, ok := counter.Count64()
+	assert.True(ok)
+	assert.Equal(int64(2558620845), count)
+	counter = gocombinatorics.OpsPosits(10).(gocombinatorics.Counter)
+	count, ok = counter.Count64()
+	assert.True(ok)
+	assert.Equal(int64(16796), count)
+}
+
+func TestTStreamCount(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TCombinations([]string{"a", "b", "c", "d"}, 2)
+	assert.Equal("6", stream.Count().String())
+	count, ok := stream.Count64()
+	assert.True(ok)
+	assert.Equal(int64(6), count)
+	var zero gocombinatorics.TStream[string]
+	assert.Zero(zero.Count().Sign())
+
+	// A TStream that cannot count its tuples is still a Counter.
+	var counter gocombinatorics.Counter = stream.Filter(
+		func(values []string) bool { return true })
+	assert.Nil(counter.Count())
+	_, ok = counter.Count64()
+	assert.False(ok)
+}
+
+// assertCount asserts that the Count of stream matches the number of
+// tuples it actually emits.
+func assertCount(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	stream.Reset()
+	counter := stream.(gocombinatorics.Counter)
+	values := make([]int, stream.TupleSize())
+	var actual int64
+	for stream.Next(values) {
+		actual++
+	}
+	assert.Equal(t, big.NewInt(actual).String(), counter.Count().String())
+	count, ok := counter.Count64()
+	assert.True(t, ok)
+	assert.Equal(t, actual, count)
+}
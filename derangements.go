@@ -0,0 +1,147 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Derangements yields all the permutations of the ints from 0 to n-1
+// inclusive where no int stays in its original position. That is, the
+// value at index i of each tuple is never i. The returned Stream's Next
+// method yields n-tuples in lexicographic order. The returned Stream
+// implements Counter.
+//
+// For instance, Derangements(4) yields
+// (1,0,3,2), (1,2,3,0), (1,3,0,2), (2,0,3,1), (2,3,0,1), (2,3,1,0),
+// (3,0,1,2), (3,2,0,1), (3,2,1,0)
+func Derangements(n int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	result := &derangements{
+		unused: newIntSet(n),
+		values: make([]int, n),
+		n:      n,
+	}
+	result.Reset()
+	return result
+}
+
+// TDerangements yields all the ways you can arrange the items slice so
+// that no item stays in its original position. The returned TStream
+// supports Count but not random access methods such as Unrank and Split.
+func TDerangements[T any](items []T) *TStream[T] {
+	return newTStream(items, len(items), func(n, k int) Stream {
+		return Derangements(n)
+	})
+}
+
+type derangements struct {
+	// Everything except the values preceding the value being changed.
+	unused intSet
+
+	// The values of the current tuple
+	values []int
+
+	n    int
+	done bool
+}
+
+func (d *derangements) TupleSize() int {
+	return d.n
+}
+
+func (d *derangements) Next(values []int) bool {
+	if len(values) < d.n {
+		panic(kSliceTooSmall)
+	}
+	if d.done {
+		return false
+	}
+	copy(values, d.values)
+	d.increment()
+	return true
+}
+
+func (d *derangements) Reset() {
+	d.done = d.n == 1
+	if d.done {
+		return
+	}
+	for i := 0; i < d.n; i++ {
+		d.unused.Add(i)
+	}
+	d.fill(0)
+}
+
+func (d *derangements) Count() *big.Int {
+	prev, current := big.NewInt(1), big.NewInt(0)
+	if d.n == 0 {
+		return prev
+	}
+	for i := 2; i <= d.n; i++ {
+		next := new(big.Int).Add(prev, current)
+		next.Mul(next, big.NewInt(int64(i-1)))
+		prev, current = current, next
+	}
+	return current
+}
+
+func (d *derangements) Count64() (int64, bool) {
+	return count64(d)
+}
+
+func (d *derangements) increment() {
+	for idx := d.n - 1; idx >= 0; idx-- {
+		d.unused.Add(d.values[idx])
+		value := d.candidate(idx, d.values[idx]+1)
+		if value != -1 {
+			d.values[idx] = value
+			d.unused.Remove(value)
+			d.fill(idx + 1)
+			return
+		}
+	}
+	d.done = true
+}
+
+// fill fills in the positions from idx onward with the smallest possible
+// values.
+func (d *derangements) fill(idx int) {
+	for ; idx < d.n; idx++ {
+		d.values[idx] = d.candidate(idx, 0)
+		d.unused.Remove(d.values[idx])
+	}
+}
+
+// candidate returns the smallest unused value greater than or equal to
+// start that can go at position idx such that the remaining positions can
+// still be filled in. If there is no such value, candidate returns -1.
+func (d *derangements) candidate(idx, start int) int {
+	value := d.unused.Next(start)
+	for ; value != -1; value = d.unused.Next(value + 1) {
+		if value == idx {
+			continue
+		}
+
+		// With two or more positions left after idx, the remaining values
+		// can always be arranged. With one position left, the one remaining
+		// value must not belong in that position.
+		if idx == d.n-2 && d.otherUnused(value) == d.n-1 {
+			continue
+		}
+		return value
+	}
+	return -1
+}
+
+// otherUnused returns the smallest unused value other than value or -1
+// if there is none.
+func (d *derangements) otherUnused(value int) int {
+	other := d.unused.Next(0)
+	for ; other != -1; other = d.unused.Next(other + 1) {
+		if other != value {
+			return other
+		}
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+package gocombinatorics_test
+
+import (
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerangements(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Derangements(4)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"1 0 3 2", "1 2 3 0", "1 3 0 2", "2 0 3 1", "2 3 0 1", "2 3 1 0",
+		"3 0 1 2", "3 2 0 1", "3 2 1 0")
+	assertStream(t, gocombinatorics.Derangements(3), "1 2 0", "2 0 1")
+	assertStream(t, gocombinatorics.Derangements(2), "1 0")
+	assertStream(t, gocombinatorics.Derangements(1))
+	assertStream(t, gocombinatorics.Derangements(0), "")
+	assert.Panics(func() { gocombinatorics.Derangements(-1) })
+}
+
+func TestDerangementsMatchPermutations(t *testing.T) {
+	for n := 0; n < 8; n++ {
+		expected := []string{}
+		stream := gocombinatorics.Permutations(n, n)
+		values := make([]int, n)
+		for stream.Next(values) {
+			if isDerangement(values) {
+				expected = append(expected, asString(values))
+			}
+		}
+		derangements := gocombinatorics.Derangements(n)
+		assert.Equal(t, expected, allTuples(derangements))
+		assertCount(t, derangements)
+	}
+}
+
+func TestTDerangements(t *testing.T) {
+	stream := gocombinatorics.TDerangements([]string{"a", "b", "c"})
+	assert.Panics(t, func() { stream.Next(nil) })
+	assertTStream(t, stream, "b c a", "c a b")
+	assert.Equal(t, "2", stream.Count().String())
+	values := make([]string, 3)
+	assert.PanicsWithValue(t, "This stream does not support Unrank.",
+		func() { stream.Unrank(0, values) })
+	assert.PanicsWithValue(t, "This stream does not support SkipTo.",
+		func() { stream.SkipTo(0) })
+	assert.PanicsWithValue(t, "This stream does not support Split.",
+		func() { stream.Split(2) })
+	assert.PanicsWithValue(t, "This stream does not support Prev.",
+		func() { stream.Prev(values) })
+	assert.PanicsWithValue(t, "This stream does not support SkipToEnd.",
+		func() { stream.SkipToEnd() })
+	assert.PanicsWithValue(t, "This stream does not support Range.",
+		func() { gocombinatorics.Range(gocombinatorics.Derangements(3), 0, 1) })
+}
+
+func isDerangement(values []int) bool {
+	for i, value := range values {
+		if value == i {
+			return false
+		}
+	}
+	return true
+}
@@ -24,3 +24,19 @@ func ExampleTCombinations() {
 	// [green blue]
 	// [yellow blue]
 }
+
+func ExampleAll() {
+	// Print out all the ways you can choose 2 numbers from 0 to 3 where
+	// order doesn't matter.
+	for values := range gocombinatorics.All(
+		gocombinatorics.Combinations(4, 2)) {
+		fmt.Println(values)
+	}
+	// Output:
+	// [0 1]
+	// [0 2]
+	// [0 3]
+	// [1 2]
+	// [1 3]
+	// [2 3]
+}
@@ -0,0 +1,192 @@
+package gocombinatorics
+
+// FlatMap returns a Stream that goes through the tuples of outer and for
+// each one, yields that tuple followed by each tuple of an inner Stream
+// that f returns. The tuple size of the returned Stream is
+// outer.TupleSize() + innerSize.
+//
+// FlatMap calls f once for each tuple of outer. f receives the outer
+// tuple along with the inner Stream that f returned last time or nil if
+// this is the first call. To avoid allocating a new inner Stream for each
+// outer tuple, f may return the previous inner Stream, changed if need be.
+// FlatMap calls Reset on whatever Stream f returns before reading from it.
+// f must not modify or retain the tuple passed to it. FlatMap panics if f
+// returns nil or a Stream with a tuple size other than innerSize.
+//
+// Reset on the returned Stream resets outer. FlatMap takes ownership of
+// outer. FlatMap panics if innerSize is negative.
+//
+// For instance, this yields each combination of k ints from 0 to n-1
+// followed by each way of permuting the positions of that combination.
+//
+//	FlatMap(
+//		Combinations(n, k),
+//		k,
+//		func(combo []int, prev Stream) Stream {
+//			if prev != nil {
+//				return prev
+//			}
+//			return Permutations(k, k)
+//		})
+func FlatMap(
+	outer Stream,
+	innerSize int,
+	f func(tuple []int, prev Stream) Stream) Stream {
+	return newFlatMapStream(outer, innerSize, f)
+}
+
+// TFlatMap works like FlatMap but for TStreams. For each tuple of outer,
+// the returned TStream yields that tuple followed by each tuple of the
+// inner TStream that f returns. f receives the inner TStream that it
+// returned last time or nil if this is the first call. f must not return
+// nil. TFlatMap calls Reset on whatever TStream f returns before reading
+// from it. Count on the returned TStream returns nil, and the returned
+// TStream does not support Unrank, Rank, SkipTo, Split, or Prev.
+//
+// For instance, this yields each combination of k items followed by each
+// arrangement of that combination.
+//
+//	TFlatMap(
+//		TCombinations(items, k),
+//		k,
+//		func(combo []T, prev *TStream[T]) *TStream[T] {
+//			return TPermutations(combo, k)
+//		})
+func TFlatMap[T any](
+	outer *TStream[T],
+	innerSize int,
+	f func(tuple []T, prev *TStream[T]) *TStream[T]) *TStream[T] {
+	stream := newFlatMapStream(outer, innerSize, f)
+
+	// The returned TStream always picks every item of stream.values in
+	// order, so stream.values acts as the current tuple.
+	return newTStreamFrom(stream.values, &identityStream{
+		advance: stream.advance,
+		size:    len(stream.values),
+		reset:   stream.Reset,
+	})
+}
+
+// innerStream is what Stream and TStream have in common.
+type innerStream[T any] interface {
+	Next(values []T) bool
+	TupleSize() int
+	Reset()
+}
+
+type flatMapStream[T any, S innerStream[T]] struct {
+	outer     S
+	innerSize int
+	f         func(tuple []T, prev S) S
+	inner     S
+	hasInner  bool
+
+	// The current outer tuple
+	outerTuple []T
+
+	// The current combined tuple
+	values []T
+}
+
+func newFlatMapStream[T any, S innerStream[T]](
+	outer S,
+	innerSize int,
+	f func(tuple []T, prev S) S) *flatMapStream[T, S] {
+	if innerSize < 0 {
+		panic("innerSize must be greater than or equal to 0")
+	}
+	outerSize := outer.TupleSize()
+	return &flatMapStream[T, S]{
+		outer:      outer,
+		innerSize:  innerSize,
+		f:          f,
+		outerTuple: make([]T, outerSize),
+		values:     make([]T, outerSize+innerSize),
+	}
+}
+
+func (f *flatMapStream[T, S]) TupleSize() int {
+	return len(f.values)
+}
+
+func (f *flatMapStream[T, S]) Next(values []T) bool {
+	if len(values) < len(f.values) {
+		panic(kSliceTooSmall)
+	}
+	if !f.advance() {
+		return false
+	}
+	copy(values, f.values)
+	return true
+}
+
+func (f *flatMapStream[T, S]) Reset() {
+	f.outer.Reset()
+	f.hasInner = false
+}
+
+// advance stores the next combined tuple in f.values and returns true.
+// If there are no more tuples, advance returns false and leaves f.values
+// unchanged.
+func (f *flatMapStream[T, S]) advance() bool {
+	outerSize := len(f.outerTuple)
+	for {
+		if !f.hasInner {
+			if !f.outer.Next(f.outerTuple) {
+				return false
+			}
+			f.inner = f.f(f.outerTuple, f.inner)
+			if isNil[T](f.inner) {
+				panic("f must not return nil")
+			}
+			if f.inner.TupleSize() != f.innerSize {
+				panic("inner stream has wrong tuple size")
+			}
+			f.inner.Reset()
+			f.hasInner = true
+		}
+
+		// Next leaves f.values alone if the inner stream has no more tuples.
+		if f.inner.Next(f.values[outerSize:]) {
+			copy(f.values, f.outerTuple)
+			return true
+		}
+		f.hasInner = false
+	}
+}
+
+// isNil returns true if inner is a nil Stream or a nil *TStream.
+func isNil[T any, S innerStream[T]](inner S) bool {
+	if stream, ok := any(inner).(*TStream[T]); ok {
+		return stream == nil
+	}
+	return any(inner) == nil
+}
+
+// identityStream yields 0, 1, 2, ... size-1 as each tuple.
+type identityStream struct {
+	advance func() bool
+	size    int
+	reset   func()
+}
+
+func (i *identityStream) TupleSize() int {
+	return i.size
+}
+
+func (i *identityStream) Next(values []int) bool {
+	if len(values) < i.size {
+		panic(kSliceTooSmall)
+	}
+	if !i.advance() {
+		return false
+	}
+	for j := 0; j < i.size; j++ {
+		values[j] = j
+	}
+	return true
+}
+
+func (i *identityStream) Reset() {
+	i.reset()
+}
@@ -0,0 +1,109 @@
+package gocombinatorics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatMap(t *testing.T) {
+	assert := assert.New(t)
+	allocations := 0
+	stream := gocombinatorics.FlatMap(
+		gocombinatorics.Combinations(3, 2),
+		2,
+		func(combo []int, prev gocombinatorics.Stream) gocombinatorics.Stream {
+			if prev != nil {
+				return prev
+			}
+			allocations++
+			return gocombinatorics.Permutations(2, 2)
+		})
+	assert.Panics(func() { stream.Next(make([]int, 3)) })
+	assert.Equal(4, stream.TupleSize())
+	assertStream(t, stream,
+		"0 1 0 1", "0 1 1 0", "0 2 0 1", "0 2 1 0", "1 2 0 1", "1 2 1 0")
+	assert.Equal(1, allocations)
+}
+
+func TestFlatMapDependent(t *testing.T) {
+	assert := assert.New(t)
+
+	// For each n from 0 to 3, all the ways to pick 1 int from 0 to n-1.
+	stream := gocombinatorics.FlatMap(
+		gocombinatorics.Product(4, 1),
+		1,
+		func(tuple []int, prev gocombinatorics.Stream) gocombinatorics.Stream {
+			return gocombinatorics.Combinations(tuple[0], 1)
+		})
+	assertStream(t, stream, "1 0", "2 0", "2 1", "3 0", "3 1", "3 2")
+
+	// Next leaves values unchanged at the end.
+	allTuples(stream)
+	values := []int{7, 7}
+	assert.False(stream.Next(values))
+	assert.Equal([]int{7, 7}, values)
+
+	assertStream(t, gocombinatorics.FlatMap(
+		gocombinatorics.Product(0, 1),
+		1,
+		func(tuple []int, prev gocombinatorics.Stream) gocombinatorics.Stream {
+			return gocombinatorics.Product(2, 1)
+		}))
+	stream = gocombinatorics.FlatMap(
+		gocombinatorics.Product(2, 1),
+		2,
+		func(tuple []int, prev gocombinatorics.Stream) gocombinatorics.Stream {
+			return gocombinatorics.Product(2, 1)
+		})
+	assert.Panics(func() { stream.Next(values) })
+	assert.Panics(func() {
+		gocombinatorics.FlatMap(gocombinatorics.Product(2, 1), -1, nil)
+	})
+	stream = gocombinatorics.FlatMap(
+		gocombinatorics.Product(2, 1),
+		1,
+		func(tuple []int, prev gocombinatorics.Stream) gocombinatorics.Stream {
+			return nil
+		})
+	assert.PanicsWithValue(
+		"f must not return nil", func() { stream.Next(values) })
+}
+
+func TestTFlatMap(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TFlatMap(
+		gocombinatorics.TCombinations([]string{"a", "b", "c"}, 2),
+		2,
+		func(
+			combo []string,
+			prev *gocombinatorics.TStream[string]) *gocombinatorics.TStream[string] {
+			return gocombinatorics.TPermutations(combo, 2)
+		})
+	assert.Panics(func() { stream.Next(make([]string, 3)) })
+	assertTStream(t, stream,
+		"a b a b", "a b b a", "a c a c", "a c c a", "b c b c", "b c c b")
+	words := gocombinatorics.TFlatMap(
+		gocombinatorics.TProduct([]string{"x", "y"}, 1),
+		1,
+		func(
+			tuple []string,
+			prev *gocombinatorics.TStream[string]) *gocombinatorics.TStream[string] {
+			return gocombinatorics.TProduct(
+				[]string{strings.ToUpper(tuple[0]), tuple[0] + tuple[0]}, 1)
+		})
+	assertTStream(t, words, "x X", "x xx", "y Y", "y yy")
+	assert.Nil(words.Count())
+	words = gocombinatorics.TFlatMap(
+		gocombinatorics.TProduct([]string{"x", "y"}, 1),
+		1,
+		func(
+			tuple []string,
+			prev *gocombinatorics.TStream[string]) *gocombinatorics.TStream[string] {
+			return nil
+		})
+	assert.PanicsWithValue(
+		"f must not return nil", func() { words.Next(make([]string, 2)) })
+}
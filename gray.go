@@ -0,0 +1,283 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Change describes how a tuple differs from the tuple before it.
+type Change struct {
+
+	// The index within the tuple that changed
+	Position int
+
+	// The value that went away
+	Old int
+
+	// The value that replaced Old
+	New int
+}
+
+// Changer is implemented by streams whose consecutive tuples differ by a
+// single value.
+type Changer interface {
+
+	// LastChange reports how the tuple that Next most recently yielded
+	// differs from the tuple Next yielded before it. LastChange returns
+	// false if Next has yielded fewer than two tuples since the stream was
+	// created or last Reset.
+	LastChange() (Change, bool)
+}
+
+// GrayCartesian yields the same tuples as Cartesian, but in reflected
+// Gray code order so that each tuple differs from the one before it in
+// just one position by exactly 1. The returned Stream implements Changer
+// and Counter.
+//
+// For instance, GrayCartesian(3, 2) yields
+// (0,0), (0,1), (1,1), (1,0), (2,0), (2,1)
+func GrayCartesian(sizes ...int) Stream {
+	checkAtLeastZero(sizes)
+	sizesCopy := make([]int, len(sizes))
+	copy(sizesCopy, sizes)
+	result := &grayCartesian{
+		sizes:      sizesCopy,
+		values:     make([]int, len(sizesCopy)),
+		directions: make([]int, len(sizesCopy)),
+	}
+	result.Reset()
+	return result
+}
+
+// GrayProduct yields the same tuples as Product, but in reflected Gray
+// code order so that each tuple differs from the one before it in just
+// one position by exactly 1. The returned Stream implements Changer and
+// Counter.
+//
+// For instance, GrayProduct(3, 2) yields
+// (0,0), (0,1), (0,2), (1,2), (1,1), (1,0), (2,0), (2,1), (2,2)
+func GrayProduct(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	sizes := make([]int, k)
+	for i := range sizes {
+		sizes[i] = n
+	}
+	return GrayCartesian(sizes...)
+}
+
+// RevolvingDoorCombinations yields the same tuples as Combinations, but
+// in revolving door order so that each tuple differs from the one before
+// it by exactly one int leaving and one int joining. Like Combinations,
+// the values in each tuple are in increasing order. The returned Stream
+// implements Changer and Counter. For the Change that LastChange returns,
+// Old is the int that left, New is the int that joined, and Position is
+// where New is in the current tuple.
+//
+// For instance, RevolvingDoorCombinations(4, 2) yields
+// (0,1), (1,2), (0,2), (2,3), (1,3), (0,3)
+func RevolvingDoorCombinations(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	result := &revolvingDoor{c: make([]int, k+2), n: n, k: k}
+	result.Reset()
+	return result
+}
+
+// changeTracker implements Changer for streams that compute each tuple in
+// advance.
+type changeTracker struct {
+
+	// How the upcoming tuple differs from the previous one
+	next    Change
+	hasNext bool
+
+	// How the tuple last yielded differs from the one before it
+	last    Change
+	hasLast bool
+}
+
+func (c *changeTracker) LastChange() (Change, bool) {
+	return c.last, c.hasLast
+}
+
+// advance records that Next just yielded the upcoming tuple.
+func (c *changeTracker) advance() {
+	c.last, c.hasLast = c.next, c.hasNext
+	c.hasNext = false
+}
+
+// setNext records how the upcoming tuple differs from the previous one.
+func (c *changeTracker) setNext(position, oldValue, newValue int) {
+	c.next = Change{Position: position, Old: oldValue, New: newValue}
+	c.hasNext = true
+}
+
+func (c *changeTracker) reset() {
+	*c = changeTracker{}
+}
+
+type grayCartesian struct {
+	changeTracker
+	sizes  []int
+	values []int
+
+	// The direction, 1 or -1, each value is moving
+	directions []int
+
+	done bool
+}
+
+func (g *grayCartesian) TupleSize() int {
+	return len(g.values)
+}
+
+func (g *grayCartesian) Next(values []int) bool {
+	if len(values) < len(g.values) {
+		panic(kSliceTooSmall)
+	}
+	if g.done {
+		return false
+	}
+	copy(values, g.values)
+	g.advance()
+	g.increment()
+	return true
+}
+
+func (g *grayCartesian) Reset() {
+	g.changeTracker.reset()
+	g.done = false
+	for i := range g.sizes {
+		if g.sizes[i] == 0 {
+			g.done = true
+			return
+		}
+	}
+	for i := range g.values {
+		g.values[i] = 0
+		g.directions[i] = 1
+	}
+}
+
+func (g *grayCartesian) Count() *big.Int {
+	return (&cartesian{sizes: g.sizes}).Count()
+}
+
+func (g *grayCartesian) Count64() (int64, bool) {
+	return count64(g)
+}
+
+func (g *grayCartesian) increment() {
+	for idx := len(g.values) - 1; idx >= 0; idx-- {
+		value := g.values[idx] + g.directions[idx]
+		if value >= 0 && value < g.sizes[idx] {
+			g.setNext(idx, g.values[idx], value)
+			g.values[idx] = value
+			return
+		}
+		g.directions[idx] = -g.directions[idx]
+	}
+	g.done = true
+}
+
+type revolvingDoor struct {
+	changeTracker
+
+	// c[1] through c[k] are the values of the current tuple. c[k+1] is n.
+	// This is the notation of Knuth's Algorithm R.
+	c []int
+
+	n    int
+	k    int
+	done bool
+}
+
+func (r *revolvingDoor) TupleSize() int {
+	return r.k
+}
+
+func (r *revolvingDoor) Next(values []int) bool {
+	if len(values) < r.k {
+		panic(kSliceTooSmall)
+	}
+	if r.done {
+		return false
+	}
+	copy(values, r.c[1:r.k+1])
+	r.advance()
+	r.increment()
+	return true
+}
+
+func (r *revolvingDoor) Reset() {
+	r.changeTracker.reset()
+	r.done = r.k > r.n
+	if r.done {
+		return
+	}
+	for j := 1; j <= r.k; j++ {
+		r.c[j] = j - 1
+	}
+	r.c[r.k+1] = r.n
+}
+
+func (r *revolvingDoor) Count() *big.Int {
+	return (&combinations{n: r.n, k: r.k}).Count()
+}
+
+func (r *revolvingDoor) Count64() (int64, bool) {
+	return count64(r)
+}
+
+// increment is Knuth's Algorithm R from The Art of Computer Programming
+// Volume 4A section 7.2.1.3.
+func (r *revolvingDoor) increment() {
+	c, k := r.c, r.k
+	if k == 0 {
+		r.done = true
+		return
+	}
+	odd := k%2 == 1
+	if odd && c[1]+1 < c[2] {
+		r.setNext(0, c[1], c[1]+1)
+		c[1]++
+		return
+	}
+	if !odd && c[1] > 0 {
+		r.setNext(0, c[1], c[1]-1)
+		c[1]--
+		return
+	}
+	tryDecrease := odd
+	for j := 2; j <= k; j++ {
+		if tryDecrease {
+
+			// Here c[j] == c[j-1]+1
+			if c[j] >= j {
+				r.setNext(j-2, c[j], j-2)
+				c[j] = c[j-1]
+				c[j-1] = j - 2
+				return
+			}
+		} else {
+
+			// Here c[j-1] == j-2
+			if c[j]+1 < c[j+1] {
+				r.setNext(j-1, c[j-1], c[j]+1)
+				c[j-1] = c[j]
+				c[j]++
+				return
+			}
+		}
+		tryDecrease = !tryDecrease
+	}
+	r.done = true
+}
@@ -0,0 +1,169 @@
+package gocombinatorics_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrayCartesian(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.GrayCartesian(3, 2)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 0", "0 1", "1 1", "1 0", "2 0", "2 1")
+	assertStream(t, gocombinatorics.GrayCartesian(), "")
+	assertStream(t, gocombinatorics.GrayCartesian(3, 0))
+	assert.Panics(func() { gocombinatorics.GrayCartesian(3, -1) })
+	for _, sizes := range [][]int{{2, 3, 4}, {1, 3, 1}, {4}, {3, 3, 3, 3}} {
+		assertGray(t, gocombinatorics.GrayCartesian(sizes...),
+			gocombinatorics.Cartesian(sizes...))
+	}
+}
+
+func TestGrayProduct(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.GrayProduct(3, 2)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"0 0", "0 1", "0 2", "1 2", "1 1", "1 0", "2 0", "2 1", "2 2")
+	assertStream(t, gocombinatorics.GrayProduct(0, 0), "")
+	assertStream(t, gocombinatorics.GrayProduct(0, 2))
+	assert.Panics(func() { gocombinatorics.GrayProduct(-1, 2) })
+	assert.Panics(func() { gocombinatorics.GrayProduct(2, -1) })
+	for n := 0; n < 5; n++ {
+		for k := 0; k < 5; k++ {
+			assertGray(t, gocombinatorics.GrayProduct(n, k),
+				gocombinatorics.Product(n, k))
+		}
+	}
+}
+
+func TestRevolvingDoorCombinations(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.RevolvingDoorCombinations(4, 2)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 1", "1 2", "0 2", "2 3", "1 3", "0 3")
+	assertStream(t, gocombinatorics.RevolvingDoorCombinations(3, 0), "")
+	assertStream(t, gocombinatorics.RevolvingDoorCombinations(3, 4))
+	assert.Panics(func() { gocombinatorics.RevolvingDoorCombinations(-1, 2) })
+	assert.Panics(func() { gocombinatorics.RevolvingDoorCombinations(2, -1) })
+	for n := 0; n < 8; n++ {
+		for k := 0; k <= n+1; k++ {
+			assertRevolvingDoor(t, n, k)
+		}
+	}
+}
+
+func TestLastChangeAfterReset(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.GrayProduct(2, 2)
+	changer := stream.(gocombinatorics.Changer)
+	values := make([]int, 2)
+	stream.Next(values)
+	stream.Next(values)
+	_, ok := changer.LastChange()
+	assert.True(ok)
+	stream.Reset()
+	_, ok = changer.LastChange()
+	assert.False(ok)
+}
+
+// assertGray asserts that stream yields the same tuples as lexStream
+// with each tuple differing from the previous in one position by 1 and
+// that LastChange reports that position.
+func assertGray(
+	t *testing.T, stream, lexStream gocombinatorics.Stream) {
+	t.Helper()
+	assert := assert.New(t)
+	assertCount(t, stream)
+	stream.Reset()
+	changer := stream.(gocombinatorics.Changer)
+	prev := make([]int, stream.TupleSize())
+	values := make([]int, stream.TupleSize())
+	actual := []string{}
+	for stream.Next(values) {
+		change, ok := changer.LastChange()
+		if len(actual) == 0 {
+			assert.False(ok)
+		} else {
+			if !assert.True(ok) {
+				return
+			}
+			for i := range values {
+				if i == change.Position {
+					assert.Equal(change.Old, prev[i])
+					assert.Equal(change.New, values[i])
+					assert.Equal(1, abs(change.New-change.Old))
+				} else {
+					assert.Equal(prev[i], values[i])
+				}
+			}
+		}
+		actual = append(actual, asString(values))
+		copy(prev, values)
+	}
+	expected := allTuples(lexStream)
+	sort.Strings(actual)
+	assert.Equal(expected, actual)
+}
+
+// assertRevolvingDoor asserts that RevolvingDoorCombinations(n, k) yields
+// the same tuples as Combinations(n, k) with each tuple differing from the
+// previous by one int leaving and one int joining and that LastChange
+// reports those ints.
+func assertRevolvingDoor(t *testing.T, n, k int) {
+	t.Helper()
+	assert := assert.New(t)
+	stream := gocombinatorics.RevolvingDoorCombinations(n, k)
+	assertCount(t, stream)
+	stream.Reset()
+	changer := stream.(gocombinatorics.Changer)
+	prev := make([]int, k)
+	values := make([]int, k)
+	seen := make(map[string]bool)
+	for stream.Next(values) {
+		assert.True(sort.IntsAreSorted(values))
+		change, ok := changer.LastChange()
+		if len(seen) == 0 {
+			assert.False(ok)
+		} else if assert.True(ok) {
+			left, joined := difference(prev, values), difference(values, prev)
+			assert.Equal([]int{change.Old}, left)
+			assert.Equal([]int{change.New}, joined)
+			assert.Equal(change.New, values[change.Position])
+		}
+		seen[asString(values)] = true
+		copy(prev, values)
+	}
+	expected := allTuples(gocombinatorics.Combinations(n, k))
+	assert.Len(seen, len(expected))
+	for _, tuple := range expected {
+		assert.True(seen[tuple])
+	}
+}
+
+// difference returns the values in x that are not in y.
+func difference(x, y []int) []int {
+	var result []int
+	for _, xv := range x {
+		found := false
+		for _, yv := range y {
+			if xv == yv {
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, xv)
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,53 @@
+package gocombinatorics
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the remaining tuples of stream for use
+// with range. The iterator yields the same slice each time, overwriting
+// its contents with each new tuple, so caller must copy a yielded tuple to
+// keep it past the current iteration. Use AllCopies to get a fresh slice
+// for each tuple. Like Next, All advances stream. If the loop stops early,
+// stream is positioned just after the last yielded tuple.
+func All(stream Stream) iter.Seq[[]int] {
+	return all(stream.TupleSize(), stream.Next, false)
+}
+
+// AllCopies works like All except that it yields a newly allocated slice
+// for each tuple which caller is free to keep.
+func AllCopies(stream Stream) iter.Seq[[]int] {
+	return all(stream.TupleSize(), stream.Next, true)
+}
+
+// All returns an iterator over the remaining tuples of this TStream for
+// use with range. The iterator yields the same slice each time,
+// overwriting its contents with each new tuple, so caller must copy a
+// yielded tuple to keep it past the current iteration. Use AllCopies to
+// get a fresh slice for each tuple. Like Next, All advances this TStream.
+// If the loop stops early, this TStream is positioned just after the last
+// yielded tuple.
+func (t *TStream[T]) All() iter.Seq[[]T] {
+	return all(t.TupleSize(), t.Next, false)
+}
+
+// AllCopies works like All except that it yields a newly allocated slice
+// for each tuple which caller is free to keep.
+func (t *TStream[T]) AllCopies() iter.Seq[[]T] {
+	return all(t.TupleSize(), t.Next, true)
+}
+
+func all[T any](
+	tupleSize int, next func(values []T) bool, copies bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		values := make([]T, tupleSize)
+		for next(values) {
+			if !yield(values) {
+				return
+			}
+			if copies {
+				values = make([]T, tupleSize)
+			}
+		}
+	}
+}
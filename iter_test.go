@@ -0,0 +1,62 @@
+package gocombinatorics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Combinations(4, 2)
+	var actual []string
+	for values := range gocombinatorics.All(stream) {
+		actual = append(actual, asString(values))
+	}
+	assert.Equal(
+		[]string{"0 1", "0 2", "0 3", "1 2", "1 3", "2 3"}, actual)
+	stream.Reset()
+	actual = nil
+	for values := range gocombinatorics.All(stream) {
+		actual = append(actual, asString(values))
+		if len(actual) == 2 {
+			break
+		}
+	}
+	assert.Equal([]string{"0 1", "0 2"}, actual)
+	assertNext(t, stream, "0 3")
+}
+
+func TestAllCopies(t *testing.T) {
+	assert := assert.New(t)
+	var actual [][]int
+	for values := range gocombinatorics.AllCopies(
+		gocombinatorics.Product(2, 2)) {
+		actual = append(actual, values)
+	}
+	assert.Equal([][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}, actual)
+}
+
+func TestTStreamAll(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TPermutations([]string{"a", "b", "c"}, 2)
+	var actual []string
+	for values := range stream.All() {
+		actual = append(actual, strings.Join(values, ""))
+	}
+	assert.Equal([]string{"ab", "ac", "ba", "bc", "ca", "cb"}, actual)
+	stream.Reset()
+	var copies [][]string
+	for values := range stream.AllCopies() {
+		copies = append(copies, values)
+	}
+	assert.Len(copies, 6)
+	assert.Equal([]string{"a", "b"}, copies[0])
+	assert.Equal([]string{"c", "b"}, copies[5])
+	var zero gocombinatorics.TStream[string]
+	for range zero.All() {
+		assert.Fail("Zero TStream should yield nothing")
+	}
+}
@@ -0,0 +1,279 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// MultisetPermutations yields all the distinct ways to arrange a multiset
+// where the int i appears counts[i] times. The returned Stream's Next
+// method yields tuples of size sum(counts) in lexicographic order. The
+// returned Stream implements Counter.
+//
+// For instance, MultisetPermutations([]int{2, 1}) yields
+// (0,0,1), (0,1,0), (1,0,0)
+func MultisetPermutations(counts []int) Stream {
+	checkAtLeastZero(counts)
+	countsCopy := append([]int(nil), counts...)
+	result := &multisetPermutations{
+		counts: countsCopy,
+		values: make([]int, sum(countsCopy)),
+	}
+	result.Reset()
+	return result
+}
+
+// TMultisetPermutations yields all the distinct ways to arrange the items
+// slice. Unlike TPermutations, TMultisetPermutations treats equal items as
+// indistinguishable, so it yields each distinct arrangement exactly once.
+// Tuples come in lexicographic order where items are ordered by where
+// they first appear in the items slice. The returned TStream supports
+// Count but not random access methods such as Unrank and Split.
+//
+// For instance, TMultisetPermutations([]string{"b", "a", "b"}) yields
+// (b,b,a), (b,a,b), (a,b,b)
+func TMultisetPermutations[T comparable](items []T) *TStream[T] {
+	distinct, counts := multiset(items)
+	return newTStreamFrom(distinct, MultisetPermutations(counts))
+}
+
+// MultisetCombinations yields all the ways you can pick k ints from a
+// multiset where the int i appears counts[i] times and where order does
+// not matter. That is, it is like CombinationsWithReplacement except
+// that the int i can appear at most counts[i] times in a tuple. The
+// returned Stream's Next method yields k-tuples in lexicographic order
+// with the values of each tuple in non-decreasing order. The returned
+// Stream implements Counter.
+//
+// For instance, MultisetCombinations([]int{2, 1, 1}, 2) yields
+// (0,0), (0,1), (0,2), (1,2)
+func MultisetCombinations(counts []int, k int) Stream {
+	checkAtLeastZero(counts)
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	countsCopy := append([]int(nil), counts...)
+
+	// suffixSums[i] is the sum of the counts from i onward.
+	suffixSums := make([]int, len(countsCopy)+1)
+	for i := len(countsCopy) - 1; i >= 0; i-- {
+		suffixSums[i] = suffixSums[i+1] + countsCopy[i]
+	}
+	result := &multisetCombinations{
+		counts:     countsCopy,
+		suffixSums: suffixSums,
+		used:       make([]int, len(countsCopy)),
+		values:     make([]int, k),
+		k:          k,
+	}
+	result.Reset()
+	return result
+}
+
+// TMultisetCombinations yields all the ways you can pick k items from the
+// items slice without replacement where order does not matter. Unlike
+// TCombinations, TMultisetCombinations treats equal items as
+// indistinguishable, so it yields each distinct combination exactly once.
+// An item can appear in a tuple as many times as it appears in the items
+// slice. Items in each tuple are ordered by where they first appear in
+// the items slice. The returned TStream supports Count but not random
+// access methods such as Unrank and Split.
+func TMultisetCombinations[T comparable](items []T, k int) *TStream[T] {
+	distinct, counts := multiset(items)
+	return newTStreamFrom(distinct, MultisetCombinations(counts, k))
+}
+
+type multisetCombinations struct {
+	counts     []int
+	suffixSums []int
+
+	// used[i] is how many times i appears in values before the position
+	// being changed.
+	used []int
+
+	values []int
+	k      int
+	done   bool
+}
+
+func (m *multisetCombinations) TupleSize() int {
+	return m.k
+}
+
+func (m *multisetCombinations) Next(values []int) bool {
+	if len(values) < m.k {
+		panic(kSliceTooSmall)
+	}
+	if m.done {
+		return false
+	}
+	copy(values, m.values)
+	m.increment()
+	return true
+}
+
+func (m *multisetCombinations) Reset() {
+	m.done = m.k > m.suffixSums[0]
+	if m.done {
+		return
+	}
+	for i := range m.used {
+		m.used[i] = 0
+	}
+	m.fill(0, 0)
+}
+
+// Count returns the coefficient of x^k in the product of
+// 1 + x + x^2 + ... + x^counts[i] over all i.
+func (m *multisetCombinations) Count() *big.Int {
+
+	// ways[j] is the number of ways to pick j ints from the counts
+	// processed so far.
+	ways := make([]*big.Int, m.k+1)
+	ways[0] = big.NewInt(1)
+	for j := 1; j <= m.k; j++ {
+		ways[j] = new(big.Int)
+	}
+	for _, count := range m.counts {
+		nextWays := make([]*big.Int, m.k+1)
+		for j := range nextWays {
+			nextWays[j] = new(big.Int)
+			for picked := 0; picked <= count && picked <= j; picked++ {
+				nextWays[j].Add(nextWays[j], ways[j-picked])
+			}
+		}
+		ways = nextWays
+	}
+	return ways[m.k]
+}
+
+func (m *multisetCombinations) Count64() (int64, bool) {
+	return count64(m)
+}
+
+func (m *multisetCombinations) increment() {
+	for idx := m.k - 1; idx >= 0; idx-- {
+		m.used[m.values[idx]]--
+
+		// Since values are non-decreasing, no value greater than
+		// m.values[idx] is used yet.
+		next := m.values[idx] + 1
+		for next < len(m.counts) && m.counts[next] == 0 {
+			next++
+		}
+		if next < len(m.counts) && m.suffixSums[next] >= m.k-idx {
+			m.fill(idx, next)
+			return
+		}
+	}
+	m.done = true
+}
+
+// fill fills in the positions from idx onward with the smallest possible
+// values that are at least value.
+func (m *multisetCombinations) fill(idx, value int) {
+	for ; idx < m.k; idx++ {
+		for m.used[value] == m.counts[value] {
+			value++
+		}
+		m.values[idx] = value
+		m.used[value]++
+	}
+}
+
+type multisetPermutations struct {
+	counts []int
+	values []int
+	done   bool
+}
+
+func (m *multisetPermutations) TupleSize() int {
+	return len(m.values)
+}
+
+func (m *multisetPermutations) Next(values []int) bool {
+	if len(values) < len(m.values) {
+		panic(kSliceTooSmall)
+	}
+	if m.done {
+		return false
+	}
+	copy(values, m.values)
+	m.increment()
+	return true
+}
+
+func (m *multisetPermutations) Reset() {
+	m.done = false
+	idx := 0
+	for value, count := range m.counts {
+		for i := 0; i < count; i++ {
+			m.values[idx] = value
+			idx++
+		}
+	}
+}
+
+// Count returns the multinomial coefficient of the counts.
+func (m *multisetPermutations) Count() *big.Int {
+	result := new(big.Int).MulRange(1, int64(len(m.values)))
+	var divisor big.Int
+	for _, count := range m.counts {
+		result.Quo(result, divisor.MulRange(1, int64(count)))
+	}
+	return result
+}
+
+func (m *multisetPermutations) Count64() (int64, bool) {
+	return count64(m)
+}
+
+func (m *multisetPermutations) increment() {
+	size := len(m.values)
+
+	// Find the rightmost value that is less than the value after it.
+	idx := size - 2
+	for idx >= 0 && m.values[idx] >= m.values[idx+1] {
+		idx--
+	}
+	if idx < 0 {
+		m.done = true
+		return
+	}
+
+	// Swap it with the rightmost value that is greater than it.
+	swapIdx := size - 1
+	for m.values[swapIdx] <= m.values[idx] {
+		swapIdx--
+	}
+	m.values[idx], m.values[swapIdx] = m.values[swapIdx], m.values[idx]
+
+	// The values after idx are now in descending order. Reverse them.
+	for i, j := idx+1, size-1; i < j; i, j = i+1, j-1 {
+		m.values[i], m.values[j] = m.values[j], m.values[i]
+	}
+}
+
+// multiset returns the distinct items in the order they first appear
+// along with how many times each distinct item appears.
+func multiset[T comparable](items []T) (distinct []T, counts []int) {
+	indexes := make(map[T]int)
+	for _, item := range items {
+		idx, ok := indexes[item]
+		if !ok {
+			idx = len(distinct)
+			indexes[item] = idx
+			distinct = append(distinct, item)
+			counts = append(counts, 0)
+		}
+		counts[idx]++
+	}
+	return
+}
+
+func sum(values []int) int {
+	result := 0
+	for _, value := range values {
+		result += value
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package gocombinatorics_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisetPermutations(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.MultisetPermutations([]int{2, 1})
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 0 1", "0 1 0", "1 0 0")
+	stream = gocombinatorics.MultisetPermutations([]int{1, 0, 2, 1})
+	assertStream(t, stream,
+		"0 2 2 3", "0 2 3 2", "0 3 2 2", "2 0 2 3", "2 0 3 2", "2 2 0 3",
+		"2 2 3 0", "2 3 0 2", "2 3 2 0", "3 0 2 2", "3 2 0 2", "3 2 2 0")
+	assertCount(t, stream)
+	assertStream(t, gocombinatorics.MultisetPermutations([]int{3}), "0 0 0")
+	assertStream(t, gocombinatorics.MultisetPermutations(nil), "")
+	assertStream(t, gocombinatorics.MultisetPermutations([]int{0, 0}), "")
+	assert.Panics(func() { gocombinatorics.MultisetPermutations([]int{1, -1}) })
+}
+
+func TestMultisetPermutationsMatchPermutations(t *testing.T) {
+	counts := []int{2, 1, 3}
+	var items []int
+	for value, count := range counts {
+		for i := 0; i < count; i++ {
+			items = append(items, value)
+		}
+	}
+	seen := make(map[string]bool)
+	var expected []string
+	stream := gocombinatorics.Permutations(len(items), len(items))
+	indexes := make([]int, len(items))
+	values := make([]int, len(items))
+	for stream.Next(indexes) {
+		for i, index := range indexes {
+			values[i] = items[index]
+		}
+		str := asString(values)
+		if !seen[str] {
+			seen[str] = true
+			expected = append(expected, str)
+		}
+	}
+	sort.Strings(expected)
+	multiset := gocombinatorics.MultisetPermutations(counts)
+	assert.Equal(t, expected, allTuples(multiset))
+	assertCount(t, multiset)
+}
+
+func TestTMultisetPermutations(t *testing.T) {
+	stream := gocombinatorics.TMultisetPermutations(
+		[]string{"b", "a", "b"})
+	assert.Panics(t, func() { stream.Next(nil) })
+	assertTStream(t, stream, "b b a", "b a b", "a b b")
+	assert.Equal(t, "3", stream.Count().String())
+	assertTStream(
+		t, gocombinatorics.TMultisetPermutations([]string{"a", "a"}), "a a")
+}
+
+func TestMultisetCombinations(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.MultisetCombinations([]int{2, 1, 1}, 2)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 0", "0 1", "0 2", "1 2")
+	stream = gocombinatorics.MultisetCombinations([]int{3, 0, 1, 2}, 3)
+	assertStream(t, stream,
+		"0 0 0", "0 0 2", "0 0 3", "0 2 3", "0 3 3", "2 3 3")
+	assertCount(t, stream)
+	stream = gocombinatorics.MultisetCombinations([]int{1, 1}, 3)
+	assertStream(t, stream)
+	assertCount(t, stream)
+	assertStream(t, gocombinatorics.MultisetCombinations([]int{1, 1}, 2), "0 1")
+	assertStream(t, gocombinatorics.MultisetCombinations(nil, 0), "")
+	assertStream(t, gocombinatorics.MultisetCombinations(nil, 1))
+	assert.Panics(func() {
+		gocombinatorics.MultisetCombinations([]int{1, -1}, 1)
+	})
+	assert.Panics(func() {
+		gocombinatorics.MultisetCombinations([]int{1, 1}, -1)
+	})
+}
+
+func TestMultisetCombinationsMatchCombinationsWithReplacement(t *testing.T) {
+	counts := []int{2, 0, 3, 1, 2}
+	for k := 0; k < 10; k++ {
+		expected := []string{}
+		stream := gocombinatorics.CombinationsWithReplacement(len(counts), k)
+		values := make([]int, k)
+		for stream.Next(values) {
+			if withinCounts(values, counts) {
+				expected = append(expected, asString(values))
+			}
+		}
+		multiset := gocombinatorics.MultisetCombinations(counts, k)
+		assert.Equal(t, expected, allTuples(multiset))
+		assertCount(t, multiset)
+	}
+}
+
+func TestTMultisetCombinations(t *testing.T) {
+	stream := gocombinatorics.TMultisetCombinations(
+		[]string{"b", "a", "b", "c"}, 2)
+	assert.Panics(t, func() { stream.Next(nil) })
+	assertTStream(t, stream, "b b", "b a", "b c", "a c")
+	assert.Equal(t, "4", stream.Count().String())
+}
+
+func withinCounts(values, counts []int) bool {
+	used := make([]int, len(counts))
+	for _, value := range values {
+		used[value]++
+		if used[value] > counts[value] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Order is the order in which a stream yields its tuples.
+type Order int
+
+const (
+	// Lex is lexicographic order, the order the first value of a tuple
+	// changes the slowest. This is the default.
+	Lex Order = iota
+
+	// Colex is colexicographic order, the order the last value of a
+	// tuple changes the slowest. For Combinations, the rank of a tuple in
+	// Colex order is its number in the combinatorial number system.
+	Colex
+
+	// RevLex is the reverse of Lex.
+	RevLex
+
+	// RevColex is the reverse of Colex.
+	RevColex
+)
+
+// CombinationsInOrder works like Combinations except that it yields the
+// tuples in the given order. Rank, Unrank, and SkipTo on the returned
+// Stream are consistent with that order.
+//
+// For instance, CombinationsInOrder(4, 2, Colex) yields
+// (0,1), (0,2), (1,2), (0,3), (1,3), (2,3)
+func CombinationsInOrder(n, k int, order Order) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	order.check()
+	result := &combinations{
+		values: make([]int, k),
+		n:      n,
+		k:      k,
+		order:  order,
+	}
+	result.Reset()
+	return result
+}
+
+// CombinationsWithReplacementInOrder works like
+// CombinationsWithReplacement except that it yields the tuples in the
+// given order. Rank, Unrank, and SkipTo on the returned Stream are
+// consistent with that order.
+//
+// For instance, CombinationsWithReplacementInOrder(3, 2, RevLex) yields
+// (2,2), (1,2), (1,1), (0,2), (0,1), (0,0)
+func CombinationsWithReplacementInOrder(n, k int, order Order) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	order.check()
+	result := &combinationsWithReplacement{
+		values: make([]int, k),
+		n:      n,
+		k:      k,
+		order:  order,
+	}
+	result.Reset()
+	return result
+}
+
+// TCombinationsInOrder works like TCombinations except that it yields the
+// tuples in the given order.
+func TCombinationsInOrder[T any](items []T, k int, order Order) *TStream[T] {
+	return newTStream(items, k, func(n, k int) Stream {
+		return CombinationsInOrder(n, k, order)
+	})
+}
+
+// TCombinationsWithReplacementInOrder works like
+// TCombinationsWithReplacement except that it yields the tuples in the
+// given order.
+func TCombinationsWithReplacementInOrder[T any](
+	items []T, k int, order Order) *TStream[T] {
+	return newTStream(items, k, func(n, k int) Stream {
+		return CombinationsWithReplacementInOrder(n, k, order)
+	})
+}
+
+// Each order is Lex order or its reverse applied to complemented tuples
+// or not. Complementing a tuple reverses it and replaces each value x
+// with n-1-x. Going through complemented tuples in Lex order visits the
+// original tuples in RevColex order.
+
+func (o Order) check() {
+	if o < Lex || o > RevColex {
+		panic("invalid order")
+	}
+}
+
+// backward returns true if o visits tuples in reverse Lex order after
+// complementing them if necessary.
+func (o Order) backward() bool {
+	return o == RevLex || o == Colex
+}
+
+// complemented returns true if o visits complemented tuples.
+func (o Order) complemented() bool {
+	return o == Colex || o == RevColex
+}
+
+// lexIndex converts index, a position in o, to the corresponding position
+// in Lex order in place or vice versa. count is the total number of
+// tuples.
+func (o Order) lexIndex(index, count *big.Int) {
+	if o.backward() {
+		index.Sub(count, index)
+		index.Sub(index, bigOne)
+	}
+}
+
+// complement complements values in place. Values range from 0 to n-1.
+func complement(values []int, n int) {
+	for i, j := 0, len(values)-1; i <= j; i, j = i+1, j-1 {
+		values[i], values[j] = n-1-values[j], n-1-values[i]
+	}
+}
+
+// lexValues returns values complemented if o calls for it so that they
+// can be ranked in Lex order. lexValues does not change values.
+func (o Order) lexValues(values []int, n int) []int {
+	if !o.complemented() {
+		return values
+	}
+	result := append([]int(nil), values...)
+	complement(result, n)
+	return result
+}
@@ -0,0 +1,167 @@
+package gocombinatorics_test
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+var allOrders = []gocombinatorics.Order{
+	gocombinatorics.Lex,
+	gocombinatorics.Colex,
+	gocombinatorics.RevLex,
+	gocombinatorics.RevColex,
+}
+
+func TestCombinationsInOrder(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.CombinationsInOrder(4, 2, gocombinatorics.Colex)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 1", "0 2", "1 2", "0 3", "1 3", "2 3")
+	assertStream(t,
+		gocombinatorics.CombinationsInOrder(4, 2, gocombinatorics.RevLex),
+		"2 3", "1 3", "1 2", "0 3", "0 2", "0 1")
+	assertStream(t,
+		gocombinatorics.CombinationsInOrder(4, 2, gocombinatorics.RevColex),
+		"2 3", "1 3", "0 3", "1 2", "0 2", "0 1")
+	assertStream(t,
+		gocombinatorics.CombinationsInOrder(4, 2, gocombinatorics.Lex),
+		"0 1", "0 2", "0 3", "1 2", "1 3", "2 3")
+	assert.Panics(func() {
+		gocombinatorics.CombinationsInOrder(4, 2, gocombinatorics.Order(4))
+	})
+	assert.Panics(func() {
+		gocombinatorics.CombinationsInOrder(-1, 2, gocombinatorics.Colex)
+	})
+	assert.Panics(func() {
+		gocombinatorics.CombinationsInOrder(4, -1, gocombinatorics.Colex)
+	})
+	for _, order := range allOrders {
+		assertStream(t, gocombinatorics.CombinationsInOrder(3, 0, order), "")
+		assertStream(t, gocombinatorics.CombinationsInOrder(3, 4, order))
+		for n := 0; n < 6; n++ {
+			for k := 0; k <= n; k++ {
+				assertOrder(t,
+					gocombinatorics.CombinationsInOrder(n, k, order),
+					gocombinatorics.Combinations(n, k),
+					order)
+			}
+		}
+		stream := gocombinatorics.CombinationsInOrder(5, 3, order)
+		assertInvalidTuple(t, stream, 1, 1, 2)
+		assertInvalidTuple(t, stream, 2, 1, 0)
+		assertInvalidTuple(t, stream, -1, 1, 2)
+		assertInvalidTuple(t, stream, 1, 2, 5)
+	}
+}
+
+func TestCombinationsInColexOrderRank(t *testing.T) {
+
+	// In colex order, the rank of a combination is the sum of binomial
+	// coefficients (values[i] choose i+1).
+	assert := assert.New(t)
+	stream := gocombinatorics.CombinationsInOrder(100, 4, gocombinatorics.Colex)
+	values := []int{3, 17, 42, 99}
+	expected := new(big.Int)
+	for i, value := range values {
+		expected.Add(
+			expected, new(big.Int).Binomial(int64(value), int64(i+1)))
+	}
+	rank, err := stream.(gocombinatorics.Ranker).RankBig(values)
+	assert.NoError(err)
+	assert.Equal(expected.String(), rank.String())
+}
+
+func TestCombinationsWithReplacementInOrder(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.CombinationsWithReplacementInOrder(
+		3, 2, gocombinatorics.RevLex)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "2 2", "1 2", "1 1", "0 2", "0 1", "0 0")
+	assertStream(t,
+		gocombinatorics.CombinationsWithReplacementInOrder(
+			3, 2, gocombinatorics.Colex),
+		"0 0", "0 1", "1 1", "0 2", "1 2", "2 2")
+	assertStream(t,
+		gocombinatorics.CombinationsWithReplacementInOrder(
+			3, 2, gocombinatorics.RevColex),
+		"2 2", "1 2", "0 2", "1 1", "0 1", "0 0")
+	assert.Panics(func() {
+		gocombinatorics.CombinationsWithReplacementInOrder(
+			3, 2, gocombinatorics.Order(-1))
+	})
+	for _, order := range allOrders {
+		assertStream(t,
+			gocombinatorics.CombinationsWithReplacementInOrder(0, 0, order), "")
+		assertStream(t,
+			gocombinatorics.CombinationsWithReplacementInOrder(0, 2, order))
+		for n := 0; n < 5; n++ {
+			for k := 0; k < 5; k++ {
+				assertOrder(t,
+					gocombinatorics.CombinationsWithReplacementInOrder(
+						n, k, order),
+					gocombinatorics.CombinationsWithReplacement(n, k),
+					order)
+			}
+		}
+		stream := gocombinatorics.CombinationsWithReplacementInOrder(
+			4, 3, order)
+		assertInvalidTuple(t, stream, 2, 1, 3)
+		assertInvalidTuple(t, stream, 0, 1, 4)
+	}
+}
+
+func TestTCombinationsInOrder(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TCombinationsInOrder(
+		[]string{"a", "b", "c", "d"}, 2, gocombinatorics.Colex)
+	assertTStream(t, stream, "a b", "a c", "b c", "a d", "b d", "c d")
+	assert.Equal("6", stream.Count().String())
+	values := make([]string, 2)
+	stream.Unrank(3, values)
+	assert.Equal([]string{"a", "d"}, values)
+	stream = gocombinatorics.TCombinationsWithReplacementInOrder(
+		[]string{"a", "b"}, 2, gocombinatorics.RevLex)
+	assertTStream(t, stream, "b b", "a b", "a a")
+}
+
+// assertOrder asserts that stream yields the same tuples as lexStream
+// but in the given order and that stream supports Count, Rank, Unrank,
+// Seek, and Split in that order.
+func assertOrder(
+	t *testing.T,
+	stream, lexStream gocombinatorics.Stream,
+	order gocombinatorics.Order) {
+	t.Helper()
+	expected := allTuples(lexStream)
+	switch order {
+	case gocombinatorics.Colex, gocombinatorics.RevColex:
+		slices.SortStableFunc(expected, func(x, y string) int {
+			return slices.Compare(reversed(x), reversed(y))
+		})
+	}
+	switch order {
+	case gocombinatorics.RevLex, gocombinatorics.RevColex:
+		slices.Reverse(expected)
+	}
+	stream.Reset()
+	if !assert.Equal(t, expected, allTuples(stream)) {
+		return
+	}
+	assertCount(t, stream)
+	assertUnrank(t, stream)
+	assertRank(t, stream)
+	assertSeek(t, stream)
+	stream.Reset()
+	assertSplit(t, stream, 3)
+}
+
+// reversed returns the tuple s as a slice in reverse order.
+func reversed(s string) []int {
+	result := parseTuple(s)
+	slices.Reverse(result)
+	return result
+}
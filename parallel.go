@@ -0,0 +1,89 @@
+package gocombinatorics
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach calls f on each remaining tuple of stream using the given number
+// of worker goroutines. Each worker passes its own slice to f, so f may
+// modify it, but f must not retain it after returning. f may be called
+// from multiple goroutines at the same time and in no particular order.
+//
+// ForEach stops early and returns the first non-nil error that f returns.
+// ForEach also stops early if ctx is done in which case it returns
+// ctx.Err(). Otherwise ForEach returns nil after f has been called on
+// every tuple. ForEach panics if workers is less than 1.
+func ForEach(
+	ctx context.Context,
+	stream Stream,
+	workers int,
+	f func(values []int) error) error {
+	return forEach(ctx, workers, stream.TupleSize(), stream.Next, f)
+}
+
+// ForEach calls f on each remaining tuple of this TStream using the given
+// number of worker goroutines. Each worker passes its own slice to f, so f
+// may modify it, but f must not retain it after returning. f may be
+// called from multiple goroutines at the same time and in no particular
+// order.
+//
+// ForEach stops early and returns the first non-nil error that f returns.
+// ForEach also stops early if ctx is done in which case it returns
+// ctx.Err(). Otherwise ForEach returns nil after f has been called on
+// every tuple. ForEach panics if workers is less than 1.
+func (t *TStream[T]) ForEach(
+	ctx context.Context, workers int, f func(values []T) error) error {
+	return forEach(ctx, workers, t.TupleSize(), t.Next, f)
+}
+
+func forEach[T any](
+	ctx context.Context,
+	workers int,
+	tupleSize int,
+	next func(values []T) bool,
+	f func(values []T) error) error {
+	if workers < 1 {
+		panic("workers must be at least 1")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mutex sync.Mutex
+	var once sync.Once
+	var firstErr error
+
+	// exhausted is true once next returns false. Guarded by mutex.
+	var exhausted bool
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			values := make([]T, tupleSize)
+			for ctx.Err() == nil {
+				mutex.Lock()
+				ok := !exhausted && next(values)
+				exhausted = !ok
+				mutex.Unlock()
+				if !ok {
+					return
+				}
+				if err := f(values); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	if exhausted {
+		return nil
+	}
+	return ctx.Err()
+}
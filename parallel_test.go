@@ -0,0 +1,106 @@
+package gocombinatorics_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Permutations(5, 3)
+	expected := allTuples(stream)
+	stream.Reset()
+	var mutex sync.Mutex
+	var actual []string
+	err := gocombinatorics.ForEach(
+		context.Background(),
+		stream,
+		4,
+		func(values []int) error {
+			str := asString(values)
+			makeZero(values)
+			mutex.Lock()
+			defer mutex.Unlock()
+			actual = append(actual, str)
+			return nil
+		})
+	assert.NoError(err)
+	sort.Strings(actual)
+	assert.Equal(expected, actual)
+	assert.Panics(func() {
+		gocombinatorics.ForEach(
+			context.Background(),
+			stream,
+			0,
+			func(values []int) error { return nil })
+	})
+}
+
+func TestForEachError(t *testing.T) {
+	assert := assert.New(t)
+	errStop := errors.New("stop")
+	var calls int64
+	err := gocombinatorics.ForEach(
+		context.Background(),
+		gocombinatorics.Product(10, 6),
+		3,
+		func(values []int) error {
+			atomic.AddInt64(&calls, 1)
+			if values[2] == 1 {
+				return errStop
+			}
+			return nil
+		})
+	assert.Same(errStop, err)
+	assert.Less(atomic.LoadInt64(&calls), int64(1000000))
+}
+
+func TestForEachCancel(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int64
+	err := gocombinatorics.ForEach(
+		ctx,
+		gocombinatorics.Product(10, 6),
+		3,
+		func(values []int) error {
+			if atomic.AddInt64(&calls, 1) == 100 {
+				cancel()
+			}
+			return nil
+		})
+	assert.Same(context.Canceled, err)
+	assert.Less(atomic.LoadInt64(&calls), int64(1000000))
+}
+
+func TestTStreamForEach(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TCombinations(
+		[]string{"red", "green", "yellow", "blue"}, 2)
+	var mutex sync.Mutex
+	var actual []string
+	err := stream.ForEach(
+		context.Background(),
+		2,
+		func(values []string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			actual = append(actual, strings.Join(values, " "))
+			return nil
+		})
+	assert.NoError(err)
+	assert.ElementsMatch(
+		[]string{
+			"red green", "red yellow", "red blue",
+			"green yellow", "green blue", "yellow blue",
+		},
+		actual)
+}
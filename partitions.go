@@ -0,0 +1,247 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// SetPartitions yields all the ways to partition the ints from 0 to n-1
+// inclusive into non-empty blocks. Each partition is an n-tuple known as a
+// restricted growth string where the value at index i is the zero based
+// block number of i. Blocks are numbered in the order of their smallest
+// element, so the first value of each tuple is always 0, and each value
+// is at most one more than the largest value before it. Tuples come in
+// lexicographic order. Use Blocks to convert a tuple to the actual blocks.
+// The returned Stream implements Counter, and its count is the nth Bell
+// number.
+//
+// For instance, SetPartitions(3) yields
+// (0,0,0), (0,0,1), (0,1,0), (0,1,1), (0,1,2)
+func SetPartitions(n int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	return newSetPartitions(n, 0, n)
+}
+
+// SetPartitionsWithBlocks works like SetPartitions except that it yields
+// only the partitions that have exactly k blocks. Its count is the
+// Stirling number of the second kind S(n, k).
+//
+// For instance, SetPartitionsWithBlocks(4, 3) yields
+// (0,0,1,2), (0,1,0,2), (0,1,1,2), (0,1,2,0), (0,1,2,1), (0,1,2,2)
+func SetPartitionsWithBlocks(n, k int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	if k < 0 {
+		panic("k must be greater than or equal to 0")
+	}
+	return newSetPartitions(n, k, k)
+}
+
+// Blocks converts a tuple from SetPartitions into the blocks it
+// represents. The items slice has the items to be partitioned and
+// partition is the tuple from SetPartitions or SetPartitionsWithBlocks
+// with item i going in block partition[i]. Blocks returns the items in
+// each block in the order they appear in the items slice. Blocks panics
+// if partition is too small to hold a tuple for items or if the first
+// len(items) values of partition are not a restricted growth string.
+//
+// For instance, Blocks([]string{"a", "b", "c"}, []int{0, 1, 0}) returns
+// [[a c] [b]]
+func Blocks[T any](items []T, partition []int) [][]T {
+	if len(partition) < len(items) {
+		panic(kSliceTooSmall)
+	}
+	var result [][]T
+	for i, item := range items {
+		block := partition[i]
+		if block < 0 || block > len(result) {
+			panic("partition must be a restricted growth string")
+		}
+		if block == len(result) {
+			result = append(result, nil)
+		}
+		result[block] = append(result[block], item)
+	}
+	return result
+}
+
+// TSetPartitions yields all the ways to partition the items slice into
+// non-empty blocks in the same order as SetPartitions.
+func TSetPartitions[T any](items []T) *TPartitionStream[T] {
+	return newTPartitionStream(items, SetPartitions(len(items)))
+}
+
+// TSetPartitionsWithBlocks works like TSetPartitions except that it
+// yields only the partitions that have exactly k blocks.
+func TSetPartitionsWithBlocks[T any](items []T, k int) *TPartitionStream[T] {
+	return newTPartitionStream(items, SetPartitionsWithBlocks(len(items), k))
+}
+
+// TPartitionStream emits partitions of a slice of items as blocks of type
+// T. The zero value emits no partitions. Copying a TPartitionStream is not
+// supported and may lead to errors.
+type TPartitionStream[T any] struct {
+	items     []T
+	partition []int
+	stream    Stream
+}
+
+func newTPartitionStream[T any](
+	items []T, stream Stream) *TPartitionStream[T] {
+	return &TPartitionStream[T]{
+		items:     append([]T(nil), items...),
+		partition: make([]int, stream.TupleSize()),
+		stream:    stream,
+	}
+}
+
+// Next returns the blocks of the next partition and true. Each call to
+// Next returns newly allocated blocks, so caller may keep them. If there
+// are no more partitions, Next returns nil and false.
+func (t *TPartitionStream[T]) Next() ([][]T, bool) {
+	if t.stream == nil || !t.stream.Next(t.partition) {
+		return nil, false
+	}
+	return Blocks(t.items, t.partition), true
+}
+
+// Reset resets this TPartitionStream to the state it had when it was
+// first created. After calling Reset, Next will yield the first
+// partition.
+func (t *TPartitionStream[T]) Reset() {
+	if t.stream != nil {
+		t.stream.Reset()
+	}
+}
+
+// Count returns the total number of partitions this TPartitionStream
+// emits from start to finish.
+func (t *TPartitionStream[T]) Count() *big.Int {
+	if t.stream == nil {
+		return new(big.Int)
+	}
+	return t.stream.(Counter).Count()
+}
+
+func newSetPartitions(n, minBlocks, maxBlocks int) Stream {
+	result := &setPartitions{
+		values:    make([]int, n),
+		blocks:    make([]int, n),
+		n:         n,
+		minBlocks: minBlocks,
+		maxBlocks: maxBlocks,
+	}
+	result.Reset()
+	return result
+}
+
+type setPartitions struct {
+	values []int
+
+	// blocks[i] is the number of blocks used by values before index i.
+	// increment uses it as scratch space.
+	blocks    []int
+	n         int
+	minBlocks int
+	maxBlocks int
+	done      bool
+}
+
+func (s *setPartitions) TupleSize() int {
+	return s.n
+}
+
+func (s *setPartitions) Next(values []int) bool {
+	if len(values) < s.n {
+		panic(kSliceTooSmall)
+	}
+	if s.done {
+		return false
+	}
+	copy(values, s.values)
+	s.increment()
+	return true
+}
+
+func (s *setPartitions) Reset() {
+	if s.n == 0 {
+		s.done = s.minBlocks > 0
+		return
+	}
+	s.done = s.minBlocks > s.n || s.maxBlocks < 1
+	if s.done {
+		return
+	}
+	s.values[0] = 0
+	s.fill(1, 1)
+}
+
+func (s *setPartitions) Count() *big.Int {
+	stirling := stirling2(s.n)
+	result := new(big.Int)
+	for k := s.minBlocks; k <= s.maxBlocks && k <= s.n; k++ {
+		result.Add(result, stirling[k])
+	}
+	return result
+}
+
+func (s *setPartitions) Count64() (int64, bool) {
+	return count64(s)
+}
+
+func (s *setPartitions) increment() {
+	blocks := s.blocks
+	for i := 1; i < s.n; i++ {
+		blocks[i] = max(blocks[i-1], s.values[i-1]+1)
+	}
+	for idx := s.n - 1; idx > 0; idx-- {
+		value := s.values[idx] + 1
+		if value > blocks[idx] || value >= s.maxBlocks {
+			continue
+		}
+		used := max(blocks[idx], value+1)
+		if s.minBlocks-used > s.n-1-idx {
+			continue
+		}
+		s.values[idx] = value
+		s.fill(idx+1, used)
+		return
+	}
+	s.done = true
+}
+
+// fill fills in the positions from idx onward with the smallest values
+// that bring the number of blocks up to s.minBlocks given that the
+// values before idx use the given number of blocks.
+func (s *setPartitions) fill(idx, blocks int) {
+	newBlocks := max(s.minBlocks-blocks, 0)
+	zeros := s.n - newBlocks
+	for i := idx; i < zeros; i++ {
+		s.values[i] = 0
+	}
+	for i := max(idx, zeros); i < s.n; i++ {
+		s.values[i] = blocks
+		blocks++
+	}
+}
+
+// stirling2 returns the Stirling numbers of the second kind S(n, k) for
+// k from 0 to n.
+func stirling2(n int) []*big.Int {
+	row := []*big.Int{big.NewInt(1)}
+	for i := 1; i <= n; i++ {
+		nextRow := make([]*big.Int, i+1)
+		nextRow[0] = new(big.Int)
+		for k := 1; k <= i; k++ {
+			nextRow[k] = new(big.Int)
+			if k < i {
+				nextRow[k].Mul(big.NewInt(int64(k)), row[k])
+			}
+			nextRow[k].Add(nextRow[k], row[k-1])
+		}
+		row = nextRow
+	}
+	return row
+}
@@ -0,0 +1,127 @@
+package gocombinatorics_test
+
+import (
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPartitions(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.SetPartitions(3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream, "0 0 0", "0 0 1", "0 1 0", "0 1 1", "0 1 2")
+	assertStream(t, gocombinatorics.SetPartitions(1), "0")
+	assertStream(t, gocombinatorics.SetPartitions(0), "")
+	assert.Panics(func() { gocombinatorics.SetPartitions(-1) })
+	bell := []string{"1", "1", "2", "5", "15", "52", "203", "877"}
+	for n, expected := range bell {
+		counter := gocombinatorics.SetPartitions(n).(gocombinatorics.Counter)
+		assert.Equal(expected, counter.Count().String())
+	}
+}
+
+func TestSetPartitionsWithBlocks(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.SetPartitionsWithBlocks(4, 3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"0 0 1 2", "0 1 0 2", "0 1 1 2", "0 1 2 0", "0 1 2 1", "0 1 2 2")
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(3, 1), "0 0 0")
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(3, 3), "0 1 2")
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(3, 4))
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(3, 0))
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(0, 0), "")
+	assertStream(t, gocombinatorics.SetPartitionsWithBlocks(0, 1))
+	assert.Panics(func() { gocombinatorics.SetPartitionsWithBlocks(-1, 1) })
+	assert.Panics(func() { gocombinatorics.SetPartitionsWithBlocks(1, -1) })
+}
+
+func TestSetPartitionsMatchProduct(t *testing.T) {
+	for n := 0; n < 7; n++ {
+		all := []string{}
+		byBlocks := make([][]string, n+2)
+		for i := range byBlocks {
+			byBlocks[i] = []string{}
+		}
+		stream := gocombinatorics.Product(n, n)
+		values := make([]int, n)
+		for stream.Next(values) {
+			if blocks, ok := restrictedGrowth(values); ok {
+				all = append(all, asString(values))
+				byBlocks[blocks] = append(byBlocks[blocks], asString(values))
+			}
+		}
+		partitions := gocombinatorics.SetPartitions(n)
+		assert.Equal(t, all, allTuples(partitions))
+		assertCount(t, partitions)
+		for k := range byBlocks {
+			partitions = gocombinatorics.SetPartitionsWithBlocks(n, k)
+			assert.Equal(t, byBlocks[k], allTuples(partitions))
+			assertCount(t, partitions)
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	assert := assert.New(t)
+	items := []string{"a", "b", "c", "d"}
+	assert.Equal(
+		[][]string{{"a", "c"}, {"b"}, {"d"}},
+		gocombinatorics.Blocks(items, []int{0, 1, 0, 2}))
+	assert.Equal(
+		[][]string{{"a", "b", "c", "d"}},
+		gocombinatorics.Blocks(items, []int{0, 0, 0, 0}))
+	assert.Empty(gocombinatorics.Blocks([]string{}, nil))
+	assert.Panics(func() { gocombinatorics.Blocks(items, []int{0, 0}) })
+	assert.Panics(func() { gocombinatorics.Blocks(items[:2], []int{0, 2}) })
+	assert.Panics(func() { gocombinatorics.Blocks(items[:2], []int{1, 0}) })
+	assert.Panics(func() { gocombinatorics.Blocks(items[:2], []int{0, -1}) })
+}
+
+func TestTSetPartitions(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TSetPartitions([]string{"a", "b", "c"})
+	assert.Equal("5", stream.Count().String())
+	expected := [][][]string{
+		{{"a", "b", "c"}},
+		{{"a", "b"}, {"c"}},
+		{{"a", "c"}, {"b"}},
+		{{"a"}, {"b", "c"}},
+		{{"a"}, {"b"}, {"c"}},
+	}
+	assert.Equal(expected, allPartitions(stream))
+	assert.Equal(expected, allPartitions(stream))
+	stream = gocombinatorics.TSetPartitionsWithBlocks([]string{"a", "b", "c"}, 2)
+	assert.Equal("3", stream.Count().String())
+	assert.Equal(expected[1:4], allPartitions(stream))
+	var zero gocombinatorics.TPartitionStream[string]
+	assert.Equal("0", zero.Count().String())
+	assert.Empty(allPartitions(&zero))
+}
+
+func allPartitions[T any](
+	stream *gocombinatorics.TPartitionStream[T]) [][][]T {
+	stream.Reset()
+	var result [][][]T
+	for blocks, ok := stream.Next(); ok; blocks, ok = stream.Next() {
+		result = append(result, blocks)
+	}
+	return result
+}
+
+// restrictedGrowth returns the number of blocks in values and true if
+// values is a restricted growth string.
+func restrictedGrowth(values []int) (int, bool) {
+	blocks := 0
+	for _, value := range values {
+		if value > blocks {
+			return 0, false
+		}
+		if value == blocks {
+			blocks++
+		}
+	}
+	return blocks, true
+}
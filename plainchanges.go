@@ -0,0 +1,146 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Swapper is implemented by streams whose consecutive tuples differ by
+// two values trading places.
+type Swapper interface {
+
+	// LastSwap reports the two positions, i < j, whose values traded
+	// places to go from the tuple Next yielded before to the tuple that
+	// Next most recently yielded. LastSwap returns false if Next has
+	// yielded fewer than two tuples since the stream was created or last
+	// Reset.
+	LastSwap() (i, j int, ok bool)
+}
+
+// PlainChangePermutations yields the same tuples as Permutations(n, n),
+// but in the minimal change order of the Steinhaus-Johnson-Trotter
+// algorithm so that each tuple differs from the one before it by two
+// adjacent values trading places. The returned Stream implements Swapper
+// and Counter.
+//
+// For instance, PlainChangePermutations(3) yields
+// (0,1,2), (0,2,1), (2,0,1), (2,1,0), (1,2,0), (1,0,2)
+func PlainChangePermutations(n int) Stream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	result := &plainChanges{
+		values:     make([]int, n),
+		directions: make([]int, n),
+		positions:  make([]int, n),
+	}
+	result.Reset()
+	return result
+}
+
+// TPlainChangePermutations yields all the ways you can arrange the items
+// slice in the minimal change order of PlainChangePermutations. Use
+// LastSwap on the returned TStream to find out which two positions traded
+// places.
+func TPlainChangePermutations[T any](items []T) *TStream[T] {
+	return newTStream(items, len(items), func(n, k int) Stream {
+		return PlainChangePermutations(n)
+	})
+}
+
+// LastSwap reports the two positions, i < j, whose items traded places to
+// go from the tuple Next yielded before to the tuple that Next most
+// recently yielded. LastSwap returns false if Next has yielded fewer than
+// two tuples since this TStream was created or last Reset or if this
+// TStream does not come from TPlainChangePermutations.
+func (t *TStream[T]) LastSwap() (i, j int, ok bool) {
+	swapper, ok := t.stream.(Swapper)
+	if !ok {
+		return 0, 0, false
+	}
+	return swapper.LastSwap()
+}
+
+type plainChanges struct {
+	values []int
+
+	// directions[x] is -1 if x is moving left or 1 if x is moving right.
+	directions []int
+
+	// positions[x] is the index of x within values
+	positions []int
+
+	// The left position of the swap that produced the upcoming tuple and
+	// the tuple last yielded. -1 means no swap.
+	nextSwap int
+	lastSwap int
+
+	done bool
+}
+
+func (p *plainChanges) TupleSize() int {
+	return len(p.values)
+}
+
+func (p *plainChanges) Next(values []int) bool {
+	if len(values) < len(p.values) {
+		panic(kSliceTooSmall)
+	}
+	if p.done {
+		return false
+	}
+	copy(values, p.values)
+	p.lastSwap, p.nextSwap = p.nextSwap, -1
+	p.increment()
+	return true
+}
+
+func (p *plainChanges) Reset() {
+	p.done = false
+	p.nextSwap = -1
+	p.lastSwap = -1
+	for i := range p.values {
+		p.values[i] = i
+		p.positions[i] = i
+		p.directions[i] = -1
+	}
+}
+
+func (p *plainChanges) LastSwap() (i, j int, ok bool) {
+	if p.lastSwap == -1 {
+		return 0, 0, false
+	}
+	return p.lastSwap, p.lastSwap + 1, true
+}
+
+func (p *plainChanges) Count() *big.Int {
+	return new(big.Int).MulRange(1, int64(len(p.values)))
+}
+
+func (p *plainChanges) Count64() (int64, bool) {
+	return count64(p)
+}
+
+func (p *plainChanges) increment() {
+
+	// Find the largest value that is mobile, that is, the largest value
+	// that is greater than its neighbor in the direction it is moving.
+	n := len(p.values)
+	for x := n - 1; x >= 0; x-- {
+		pos := p.positions[x]
+		neighborPos := pos + p.directions[x]
+		if neighborPos < 0 || neighborPos >= n || p.values[neighborPos] > x {
+			continue
+		}
+		neighbor := p.values[neighborPos]
+		p.values[pos], p.values[neighborPos] = neighbor, x
+		p.positions[x], p.positions[neighbor] = neighborPos, pos
+		p.nextSwap = min(pos, neighborPos)
+
+		// Values greater than x change direction.
+		for y := x + 1; y < n; y++ {
+			p.directions[y] = -p.directions[y]
+		}
+		return
+	}
+	p.done = true
+}
@@ -0,0 +1,82 @@
+package gocombinatorics_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainChangePermutations(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.PlainChangePermutations(3)
+	assert.Panics(func() { stream.Next(nil) })
+	assertStream(t, stream,
+		"0 1 2", "0 2 1", "2 0 1", "2 1 0", "1 2 0", "1 0 2")
+	assertStream(t, gocombinatorics.PlainChangePermutations(1), "0")
+	assertStream(t, gocombinatorics.PlainChangePermutations(0), "")
+	assert.Panics(func() { gocombinatorics.PlainChangePermutations(-1) })
+	for n := 0; n < 7; n++ {
+		assertPlainChanges(t, gocombinatorics.PlainChangePermutations(n))
+	}
+}
+
+func TestTPlainChangePermutations(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TPlainChangePermutations([]string{"a", "b", "c"})
+	assertTStream(t, stream,
+		"a b c", "a c b", "c a b", "c b a", "b c a", "b a c")
+	assert.Equal("6", stream.Count().String())
+	stream.Reset()
+	values := make([]string, 3)
+	stream.Next(values)
+	_, _, ok := stream.LastSwap()
+	assert.False(ok)
+	stream.Next(values)
+	stream.Next(values)
+	i, j, ok := stream.LastSwap()
+	assert.True(ok)
+	assert.Equal(0, i)
+	assert.Equal(1, j)
+	stream.Reset()
+	_, _, ok = stream.LastSwap()
+	assert.False(ok)
+
+	lexStream := gocombinatorics.TPermutations([]string{"a", "b"}, 2)
+	lexStream.Next(values)
+	lexStream.Next(values)
+	_, _, ok = lexStream.LastSwap()
+	assert.False(ok)
+	_, _, ok = (&gocombinatorics.TStream[string]{}).LastSwap()
+	assert.False(ok)
+}
+
+// assertPlainChanges asserts that stream yields the same tuples as
+// Permutations(n, n) with each tuple differing from the previous by two
+// adjacent values trading places and that LastSwap reports those places.
+func assertPlainChanges(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	assert := assert.New(t)
+	assertCount(t, stream)
+	stream.Reset()
+	n := stream.TupleSize()
+	swapper := stream.(gocombinatorics.Swapper)
+	prev := make([]int, n)
+	values := make([]int, n)
+	actual := []string{}
+	for stream.Next(values) {
+		i, j, ok := swapper.LastSwap()
+		if len(actual) == 0 {
+			assert.False(ok)
+		} else if assert.True(ok) {
+			assert.Equal(i+1, j)
+			prev[i], prev[j] = prev[j], prev[i]
+			assert.Equal(prev, values)
+		}
+		actual = append(actual, asString(values))
+		copy(prev, values)
+	}
+	sort.Strings(actual)
+	assert.Equal(allTuples(gocombinatorics.Permutations(n, n)), actual)
+}
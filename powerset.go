@@ -0,0 +1,152 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// PowerSet yields all the subsets of the ints from 0 to n-1 inclusive.
+// Each subset is a tuple with its values in increasing order. The
+// returned VarStream yields the empty subset first, then all the subsets
+// of size 1, then all the subsets of size 2 etc. Subsets of the same size
+// come in lexicographic order. The returned VarStream implements Counter.
+//
+// For instance, PowerSet(3) yields
+// (), (0), (1), (2), (0,1), (0,2), (1,2), (0,1,2)
+func PowerSet(n int) VarStream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	result := &powerSet{
+		combos: combinations{values: make([]int, 0, n), n: n},
+	}
+	result.Reset()
+	return result
+}
+
+// PowerSetBinary works like PowerSet except that it yields subsets in
+// binary counting order. That is, the ith subset contains j if and only
+// if bit j of i is set.
+//
+// For instance, PowerSetBinary(3) yields
+// (), (0), (1), (0,1), (2), (0,2), (1,2), (0,1,2)
+func PowerSetBinary(n int) VarStream {
+	if n < 0 {
+		panic("n must be greater than or equal to 0")
+	}
+	return &binaryPowerSet{bits: make([]bool, n)}
+}
+
+// TPowerSet yields all the subsets of the items slice. Items in each
+// subset appear in the same order as in the items slice. The returned
+// TVarStream yields the empty subset first, then all the subsets of size
+// 1, then all the subsets of size 2 etc.
+func TPowerSet[T any](items []T) *TVarStream[T] {
+	return newTVarStreamFrom(
+		append([]T(nil), items...), PowerSet(len(items)))
+}
+
+// TPowerSetBinary works like TPowerSet except that it yields subsets in
+// binary counting order. That is, the ith subset contains items[j] if and
+// only if bit j of i is set.
+func TPowerSetBinary[T any](items []T) *TVarStream[T] {
+	return newTVarStreamFrom(
+		append([]T(nil), items...), PowerSetBinary(len(items)))
+}
+
+type powerSet struct {
+
+	// The subsets of the current size
+	combos combinations
+}
+
+func (p *powerSet) MaxTupleSize() int {
+	return p.combos.n
+}
+
+func (p *powerSet) NextN(values []int) (int, bool) {
+	if len(values) < p.combos.n {
+		panic(kSliceTooSmall)
+	}
+	for !p.combos.Next(values) {
+		if p.combos.k == p.combos.n {
+			return 0, false
+		}
+		p.setSize(p.combos.k + 1)
+	}
+	return p.combos.k, true
+}
+
+func (p *powerSet) Reset() {
+	p.setSize(0)
+}
+
+func (p *powerSet) Count() *big.Int {
+	return powerOfTwo(p.combos.n)
+}
+
+func (p *powerSet) Count64() (int64, bool) {
+	return count64(p)
+}
+
+func (p *powerSet) setSize(k int) {
+	p.combos.k = k
+	p.combos.values = p.combos.values[:k]
+	p.combos.Reset()
+}
+
+type binaryPowerSet struct {
+	bits []bool
+	done bool
+}
+
+func (b *binaryPowerSet) MaxTupleSize() int {
+	return len(b.bits)
+}
+
+func (b *binaryPowerSet) NextN(values []int) (int, bool) {
+	if len(values) < len(b.bits) {
+		panic(kSliceTooSmall)
+	}
+	if b.done {
+		return 0, false
+	}
+	size := 0
+	for i, bit := range b.bits {
+		if bit {
+			values[size] = i
+			size++
+		}
+	}
+	b.increment()
+	return size, true
+}
+
+func (b *binaryPowerSet) Reset() {
+	b.done = false
+	for i := range b.bits {
+		b.bits[i] = false
+	}
+}
+
+func (b *binaryPowerSet) Count() *big.Int {
+	return powerOfTwo(len(b.bits))
+}
+
+func (b *binaryPowerSet) Count64() (int64, bool) {
+	return count64(b)
+}
+
+func (b *binaryPowerSet) increment() {
+	for i := range b.bits {
+		if !b.bits[i] {
+			b.bits[i] = true
+			return
+		}
+		b.bits[i] = false
+	}
+	b.done = true
+}
+
+func powerOfTwo(n int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(n))
+}
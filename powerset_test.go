@@ -0,0 +1,45 @@
+package gocombinatorics_test
+
+import (
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerSet(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.PowerSet(3)
+	assert.Panics(func() { stream.NextN(nil) })
+	assertVarStream(t, stream,
+		"", "0", "1", "2", "0 1", "0 2", "1 2", "0 1 2")
+	assertVarStream(t, gocombinatorics.PowerSet(1), "", "0")
+	assertVarStream(t, gocombinatorics.PowerSet(0), "")
+	assert.Equal(
+		"1024",
+		gocombinatorics.PowerSet(10).(gocombinatorics.Counter).Count().String())
+	assert.Panics(func() { gocombinatorics.PowerSet(-1) })
+}
+
+func TestPowerSetBinary(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.PowerSetBinary(3)
+	assert.Panics(func() { stream.NextN(nil) })
+	assertVarStream(t, stream,
+		"", "0", "1", "0 1", "2", "0 2", "1 2", "0 1 2")
+	assertVarStream(t, gocombinatorics.PowerSetBinary(1), "", "0")
+	assertVarStream(t, gocombinatorics.PowerSetBinary(0), "")
+	counter := gocombinatorics.PowerSetBinary(70).(gocombinatorics.Counter)
+	assert.Equal("1180591620717411303424", counter.Count().String())
+	assert.Panics(func() { gocombinatorics.PowerSetBinary(-1) })
+}
+
+func TestTPowerSet(t *testing.T) {
+	stream := gocombinatorics.TPowerSet([]string{"a", "b", "c"})
+	assert.Panics(t, func() { stream.NextN(nil) })
+	assertTVarStream(t, stream,
+		"", "a", "b", "c", "a b", "a c", "b c", "a b c")
+	stream = gocombinatorics.TPowerSetBinary([]string{"a", "b", "c"})
+	assertTVarStream(t, stream,
+		"", "a", "b", "a b", "c", "a c", "b c", "a b c")
+}
@@ -0,0 +1,286 @@
+package gocombinatorics
+
+// Bidirectional is implemented by streams that can move backward as well
+// as forward. Think of a Bidirectional stream as a cursor that sits
+// between two tuples: Next yields the tuple after the cursor and moves
+// the cursor forward; Prev yields the tuple before the cursor and moves
+// the cursor backward. So calling Prev right after Next yields the same
+// tuple again. The streams that Combinations, CombinationsWithReplacement,
+// Permutations, Product, Cartesian, and OpsPosits return implement
+// Bidirectional.
+type Bidirectional interface {
+
+	// Prev populates values with the previous tuple and returns true. If
+	// the stream is at the beginning, Prev returns false and leaves values
+	// unchanged. Caller must pass in a slice big enough to hold a tuple.
+	Prev(values []int) bool
+
+	// SkipToEnd positions the stream past its last tuple so that Next
+	// returns false and Prev yields the last tuple.
+	SkipToEnd()
+}
+
+func (c *combinations) Prev(values []int) bool {
+	return retreat(c, &c.done, c.values, func() bool {
+		return c.step(false)
+	}, values)
+}
+
+func (c *combinations) SkipToEnd() {
+	c.done = true
+}
+
+func (c *combinationsWithReplacement) Prev(values []int) bool {
+	return retreat(c, &c.done, c.values, func() bool {
+		return c.step(false)
+	}, values)
+}
+
+func (c *combinationsWithReplacement) SkipToEnd() {
+	c.done = true
+}
+
+func (o *opsPosits) Prev(values []int) bool {
+	return retreat(o, &o.done, o.values, o.decrement, values)
+}
+
+func (o *opsPosits) SkipToEnd() {
+	o.done = true
+}
+
+func (p *permutations) Prev(values []int) bool {
+	wasDone := p.done
+	if !retreat(p, &p.done, p.values, p.decrement, values) {
+		return false
+	}
+
+	// decrement keeps p.unused in sync but Unrank does not.
+	if wasDone {
+		p.syncUnused()
+	}
+	return true
+}
+
+func (p *permutations) SkipToEnd() {
+	p.done = true
+}
+
+func (c *cartesian) Prev(values []int) bool {
+	return retreat(c, &c.done, c.values, c.decrement, values)
+}
+
+func (c *cartesian) SkipToEnd() {
+	c.done = true
+}
+
+func (p *product) Prev(values []int) bool {
+	return retreat(p, &p.done, p.values, p.decrement, values)
+}
+
+func (p *product) SkipToEnd() {
+	p.done = true
+}
+
+// Prev populates values with the previous tuple and returns true. If this
+// TStream is at the beginning, Prev returns false and leaves values
+// unchanged. Calling Prev right after Next yields the same tuple again.
+// Prev panics if this TStream does not support it. Caller must pass in a
+// slice big enough to hold a tuple.
+func (t *TStream[T]) Prev(values []T) bool {
+	if len(values) < len(t.indexes) {
+		panic(kSliceTooSmall)
+	}
+	if t.stream == nil {
+		return false
+	}
+	if !mustSupport[Bidirectional](t.stream, "Prev").Prev(t.indexes) {
+		return false
+	}
+	for i := range t.indexes {
+		values[i] = t.items[t.indexes[i]]
+	}
+	return true
+}
+
+// SkipToEnd positions this TStream past its last tuple so that Next
+// returns false and Prev yields the last tuple. SkipToEnd panics if this
+// TStream does not support it.
+func (t *TStream[T]) SkipToEnd() {
+	if t.stream != nil {
+		mustSupport[Bidirectional](t.stream, "SkipToEnd").SkipToEnd()
+	}
+}
+
+// retreat implements Prev for the streams in this package. current is the
+// tuple that stream yields next unless done is true. decrement moves
+// current to the previous tuple or returns false if there is none.
+// retreat copies the previous tuple into values.
+func retreat(
+	stream interface {
+		Stream
+		Counter
+		Unranker
+	},
+	done *bool,
+	current []int,
+	decrement func() bool,
+	values []int) bool {
+	if len(values) < stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if *done {
+		count := stream.Count()
+		if count.Sign() == 0 {
+			return false
+		}
+		stream.UnrankBig(count.Sub(count, bigOne), current)
+		*done = false
+	} else if !decrement() {
+		return false
+	}
+	copy(values, current)
+	return true
+}
+
+// decrement moves values to the previous tuple in Lex order. If values is
+// the first tuple, decrement returns false and leaves values unchanged.
+func (c *combinations) decrement() bool {
+	idx := c.k - 1
+	for idx >= 0 && c.values[idx] == c.lowest(idx) {
+		idx--
+	}
+	if idx < 0 {
+		return false
+	}
+	c.values[idx]--
+	for i := idx + 1; i < c.k; i++ {
+		c.values[i] = c.n - c.k + i
+	}
+	return true
+}
+
+// lowest returns the smallest value possible at idx given the values
+// before it.
+func (c *combinations) lowest(idx int) int {
+	if idx == 0 {
+		return 0
+	}
+	return c.values[idx-1] + 1
+}
+
+// decrement moves values to the previous tuple in Lex order. If values is
+// the first tuple, decrement returns false and leaves values unchanged.
+func (c *combinationsWithReplacement) decrement() bool {
+	idx := c.k - 1
+	for idx >= 0 && c.values[idx] == c.lowest(idx) {
+		idx--
+	}
+	if idx < 0 {
+		return false
+	}
+	c.values[idx]--
+	for i := idx + 1; i < c.k; i++ {
+		c.values[i] = c.n - 1
+	}
+	return true
+}
+
+// lowest returns the smallest value possible at idx given the values
+// before it.
+func (c *combinationsWithReplacement) lowest(idx int) int {
+	if idx == 0 {
+		return 0
+	}
+	return c.values[idx-1]
+}
+
+func (o *opsPosits) decrement() bool {
+	idx := o.k - 1
+	for idx >= 0 && o.values[idx] == o.lowest(idx) {
+		idx--
+	}
+	if idx < 0 {
+		return false
+	}
+	o.values[idx]--
+	for i := idx + 1; i < o.k; i++ {
+		o.values[i] = o.k
+	}
+	return true
+}
+
+// lowest returns the smallest value possible at idx given the values
+// before it.
+func (o *opsPosits) lowest(idx int) int {
+	if idx == 0 {
+		return 1
+	}
+	return max(o.values[idx-1], idx+1)
+}
+
+// decrement relies on and preserves the invariant that p.unused contains
+// everything except the values preceding the last value.
+func (p *permutations) decrement() bool {
+	for idx := p.k - 1; idx >= 0; idx-- {
+
+		// Make p.unused contain everything except the values preceding idx.
+		if idx < p.k-1 {
+			p.unused.Add(p.values[idx])
+		}
+		value := p.values[idx] - 1
+		for value >= 0 && !p.unused.Contains(value) {
+			value--
+		}
+		if value < 0 {
+			continue
+		}
+		p.values[idx] = value
+
+		// Fill in the rest with the largest values in decreasing order.
+		value = p.n - 1
+		for i := idx + 1; i < p.k; i++ {
+			p.unused.Remove(p.values[i-1])
+			for !p.unused.Contains(value) {
+				value--
+			}
+			p.values[i] = value
+		}
+		return true
+	}
+
+	// Restore the invariant.
+	for idx := 0; idx < p.k-1; idx++ {
+		p.unused.Remove(p.values[idx])
+	}
+	return false
+}
+
+func (c *cartesian) decrement() bool {
+	idx := len(c.values) - 1
+	for idx >= 0 && c.values[idx] == 0 {
+		idx--
+	}
+	if idx < 0 {
+		return false
+	}
+	c.values[idx]--
+	for i := idx + 1; i < len(c.values); i++ {
+		c.values[i] = c.sizes[i] - 1
+	}
+	return true
+}
+
+func (p *product) decrement() bool {
+	idx := p.k - 1
+	for idx >= 0 && p.values[idx] == 0 {
+		idx--
+	}
+	if idx < 0 {
+		return false
+	}
+	p.values[idx]--
+	for i := idx + 1; i < p.k; i++ {
+		p.values[i] = p.n - 1
+	}
+	return true
+}
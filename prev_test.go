@@ -0,0 +1,141 @@
+package gocombinatorics_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrev(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Combinations(4, 2)
+	bidi := stream.(gocombinatorics.Bidirectional)
+	values := make([]int, 2)
+	assert.Panics(func() { bidi.Prev(nil) })
+	assert.False(bidi.Prev(values))
+	assertNext(t, stream, "0 1", "0 2", "0 3")
+	assertPrev(t, bidi, 2, "0 3", "0 2", "0 1")
+	assert.False(bidi.Prev(values))
+	assertNext(t, stream, "0 1", "0 2")
+	bidi.SkipToEnd()
+	assert.False(stream.Next(values))
+	assertPrev(t, bidi, 2, "2 3", "1 3")
+	assertNext(t, stream, "1 3", "2 3")
+	assert.False(stream.Next(values))
+}
+
+func TestPrevAllStreams(t *testing.T) {
+	streams := []gocombinatorics.Stream{
+		gocombinatorics.Combinations(5, 3),
+		gocombinatorics.Combinations(3, 0),
+		gocombinatorics.Combinations(3, 4),
+		gocombinatorics.CombinationsWithReplacement(4, 3),
+		gocombinatorics.CombinationsWithReplacement(0, 2),
+		gocombinatorics.Permutations(4, 2),
+		gocombinatorics.Permutations(4, 4),
+		gocombinatorics.Permutations(3, 0),
+		gocombinatorics.Permutations(2, 3),
+		gocombinatorics.Product(3, 3),
+		gocombinatorics.Product(0, 0),
+		gocombinatorics.Cartesian(3, 1, 2),
+		gocombinatorics.Cartesian(3, 0, 2),
+		gocombinatorics.OpsPosits(4),
+		gocombinatorics.OpsPosits(0),
+	}
+	for _, order := range allOrders {
+		streams = append(
+			streams,
+			gocombinatorics.CombinationsInOrder(5, 3, order),
+			gocombinatorics.CombinationsWithReplacementInOrder(3, 3, order))
+	}
+	for _, stream := range streams {
+		assertBidirectional(t, stream)
+	}
+}
+
+func TestPermutationsPrev(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Permutations(4, 3)
+	bidi := stream.(gocombinatorics.Bidirectional)
+	values := make([]int, 3)
+	assertNext(t, stream, "0 1 2")
+	assertPrev(t, bidi, 3, "0 1 2")
+	assert.False(bidi.Prev(values))
+	assertNext(t, stream, "0 1 2", "0 1 3", "0 2 1")
+	seeker := stream.(gocombinatorics.Seeker)
+	seeker.SkipTo(12)
+	assert.Zero(testing.AllocsPerRun(10, func() {
+		bidi.Prev(values)
+		stream.Next(values)
+	}))
+}
+
+func TestTStreamPrev(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TPermutations([]string{"a", "b", "c"}, 2)
+	values := make([]string, 2)
+	assert.Panics(func() { stream.Prev(nil) })
+	assert.False(stream.Prev(values))
+	stream.SkipToEnd()
+	assert.True(stream.Prev(values))
+	assert.Equal([]string{"c", "b"}, values)
+	assert.True(stream.Prev(values))
+	assert.Equal([]string{"c", "a"}, values)
+	assert.True(stream.Next(values))
+	assert.Equal([]string{"c", "a"}, values)
+	var zero gocombinatorics.TStream[string]
+	zero.SkipToEnd()
+	assert.False(zero.Prev(nil))
+}
+
+// assertPrev asserts that the tuples stream yields going backward are
+// results.
+func assertPrev(
+	t *testing.T,
+	stream gocombinatorics.Bidirectional,
+	tupleSize int,
+	results ...string) {
+	t.Helper()
+	values := make([]int, tupleSize)
+	for _, result := range results {
+		if !assert.True(t, stream.Prev(values)) {
+			return
+		}
+		if !assert.Equal(t, result, asString(values)) {
+			return
+		}
+	}
+}
+
+// assertBidirectional asserts that going backward through stream yields
+// its tuples in reverse order and that Prev moves back to where Next
+// started from.
+func assertBidirectional(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	assert := assert.New(t)
+	bidi := stream.(gocombinatorics.Bidirectional)
+	stream.Reset()
+	expected := allTuples(stream)
+	backward := []string{}
+	values := make([]int, stream.TupleSize())
+	for bidi.Prev(values) {
+		backward = append(backward, asString(values))
+	}
+	slices.Reverse(backward)
+	assert.Equal(expected, backward)
+	bidi.SkipToEnd()
+	assert.False(stream.Next(values))
+	seeker := stream.(gocombinatorics.Seeker)
+	for i := range expected {
+		seeker.SkipTo(int64(i + 1))
+		assert.True(bidi.Prev(values))
+		assert.Equal(expected[i], asString(values))
+		assert.Equal(expected[i:], allTuples(stream))
+		seeker.SkipTo(int64(i))
+		assert.True(stream.Next(values))
+		assert.True(bidi.Prev(values))
+		assert.Equal(expected[i], asString(values))
+	}
+}
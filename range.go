@@ -0,0 +1,140 @@
+package gocombinatorics
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// rankedStream is a Stream that supports random access.
+type rankedStream interface {
+	Stream
+	Counter
+	Unranker
+	Ranker
+	Seeker
+}
+
+// Range returns a Stream that yields only the tuples of stream with
+// zero based indexes from start up to but not including end. Reset on the
+// returned Stream goes back to the tuple at start. The returned Stream has
+// the same tuple size as stream and supports Counter, Unranker, Ranker,
+// and Seeker with indexes relative to start. The returned Stream
+// supports Split only if stream does.
+//
+// stream must implement Counter, Unranker, Ranker, and Seeker. Range
+// takes ownership of stream, so caller should not use stream after
+// calling Range. Range panics if stream does not support it, if start is
+// negative, if start is greater than end, or if end is greater than the
+// number of tuples in stream.
+func Range(stream Stream, start, end int64) Stream {
+	return RangeBig(stream, big.NewInt(start), big.NewInt(end))
+}
+
+// RangeBig works like Range but accepts start and end of any size.
+func RangeBig(stream Stream, start, end *big.Int) Stream {
+	ranked := mustSupport[rankedStream](stream, "Range")
+	if start.Sign() < 0 || start.Cmp(end) > 0 || end.Cmp(ranked.Count()) > 0 {
+		panic(kIndexOutOfRange)
+	}
+	result := &rangeStream{
+		stream:    ranked,
+		start:     new(big.Int).Set(start),
+		count:     new(big.Int).Sub(end, start),
+		remaining: new(big.Int),
+	}
+	result.Reset()
+	if _, ok := stream.(splittableStream); !ok {
+
+		// Hide position and clone so that Split rejects the returned
+		// Stream since it cannot copy stream.
+		return struct{ rankedStream }{result}
+	}
+	return result
+}
+
+var bigOne = big.NewInt(1)
+
+type rangeStream struct {
+	stream    rankedStream
+	start     *big.Int
+	count     *big.Int
+	remaining *big.Int
+}
+
+func (r *rangeStream) TupleSize() int {
+	return r.stream.TupleSize()
+}
+
+func (r *rangeStream) Next(values []int) bool {
+	if len(values) < r.stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if r.remaining.Sign() == 0 {
+		return false
+	}
+	r.stream.Next(values)
+	r.remaining.Sub(r.remaining, bigOne)
+	return true
+}
+
+func (r *rangeStream) Reset() {
+	r.stream.SkipToBig(r.start)
+	r.remaining.Set(r.count)
+}
+
+func (r *rangeStream) Count() *big.Int {
+	return new(big.Int).Set(r.count)
+}
+
+func (r *rangeStream) Count64() (int64, bool) {
+	return count64(r)
+}
+
+func (r *rangeStream) Unrank(index int64, values []int) {
+	r.UnrankBig(big.NewInt(index), values)
+}
+
+func (r *rangeStream) UnrankBig(index *big.Int, values []int) {
+	if index.Sign() < 0 || index.Cmp(r.count) >= 0 {
+		panic(kIndexOutOfRange)
+	}
+	r.stream.UnrankBig(new(big.Int).Add(r.start, index), values)
+}
+
+func (r *rangeStream) Rank(values []int) (int64, error) {
+	return rank64(r, values)
+}
+
+func (r *rangeStream) RankBig(values []int) (*big.Int, error) {
+	result, err := r.stream.RankBig(values)
+	if err != nil {
+		return nil, err
+	}
+	result.Sub(result, r.start)
+	if result.Sign() < 0 || result.Cmp(r.count) >= 0 {
+		return nil, fmt.Errorf(
+			"%w: %v is outside of range", ErrInvalidTuple, values)
+	}
+	return result, nil
+}
+
+func (r *rangeStream) SkipTo(index int64) {
+	r.SkipToBig(big.NewInt(index))
+}
+
+func (r *rangeStream) SkipToBig(index *big.Int) {
+	if index.Sign() < 0 || index.Cmp(r.count) > 0 {
+		panic(kIndexOutOfRange)
+	}
+	r.stream.SkipToBig(new(big.Int).Add(r.start, index))
+	r.remaining.Sub(r.count, index)
+}
+
+func (r *rangeStream) SkipToTuple(values []int) error {
+	index, err := r.RankBig(values)
+	if err != nil {
+		return err
+	}
+	r.SkipToBig(index)
+	return nil
+}
@@ -0,0 +1,67 @@
+package gocombinatorics_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange(t *testing.T) {
+	stream := gocombinatorics.Range(gocombinatorics.Combinations(5, 3), 3, 7)
+	assert.Equal(t, 3, stream.TupleSize())
+	assert.Panics(t, func() { stream.Next(nil) })
+	assertStream(t, stream, "0 2 3", "0 2 4", "0 3 4", "1 2 3")
+	assertCount(t, stream)
+	assertUnrank(t, stream)
+	assertRank(t, stream)
+	assertSeek(t, stream)
+
+	stream = gocombinatorics.Range(gocombinatorics.Permutations(3, 2), 6, 6)
+	assertStream(t, stream)
+	stream = gocombinatorics.Range(gocombinatorics.Product(2, 2), 0, 4)
+	assertStream(t, stream, "0 0", "0 1", "1 0", "1 1")
+	stream = gocombinatorics.Range(gocombinatorics.OpsPosits(3), 4, 5)
+	assertStream(t, stream, "3 3 3")
+}
+
+func TestRangeOfRange(t *testing.T) {
+	stream := gocombinatorics.Range(
+		gocombinatorics.Range(gocombinatorics.Cartesian(3, 2), 1, 5), 1, 3)
+	assertStream(t, stream, "1 0", "1 1")
+}
+
+func TestRangeRankOutside(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Range(
+		gocombinatorics.CombinationsWithReplacement(3, 2), 2, 4)
+	ranker := stream.(gocombinatorics.Ranker)
+	_, err := ranker.Rank([]int{0, 1})
+	assert.True(errors.Is(err, gocombinatorics.ErrInvalidTuple))
+	_, err = ranker.Rank([]int{1, 0})
+	assert.True(errors.Is(err, gocombinatorics.ErrInvalidTuple))
+	rank, err := ranker.Rank([]int{1, 1})
+	assert.NoError(err)
+	assert.Equal(int64(1), rank)
+	err = stream.(gocombinatorics.Seeker).SkipToTuple([]int{2, 2})
+	assert.True(errors.Is(err, gocombinatorics.ErrInvalidTuple))
+}
+
+func TestRangePanics(t *testing.T) {
+	assert := assert.New(t)
+	assert.Panics(func() {
+		gocombinatorics.Range(gocombinatorics.Combinations(5, 3), -1, 3)
+	})
+	assert.Panics(func() {
+		gocombinatorics.Range(gocombinatorics.Combinations(5, 3), 4, 3)
+	})
+	assert.Panics(func() {
+		gocombinatorics.Range(gocombinatorics.Combinations(5, 3), 3, 11)
+	})
+	stream := gocombinatorics.Range(gocombinatorics.Combinations(5, 3), 3, 7)
+	assert.Panics(func() { stream.(gocombinatorics.Seeker).SkipTo(5) })
+	assert.Panics(func() {
+		stream.(gocombinatorics.Unranker).Unrank(4, make([]int, 3))
+	})
+}
@@ -0,0 +1,425 @@
+package gocombinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	kIndexOutOfRange = "Index out of range."
+)
+
+var (
+	// ErrInvalidTuple indicates that a tuple is not one that a stream emits.
+	ErrInvalidTuple = errors.New("gocombinatorics: invalid tuple")
+
+	// ErrOverflow indicates that a rank does not fit in an int64.
+	ErrOverflow = errors.New("gocombinatorics: rank overflows int64")
+)
+
+// Unranker is implemented by streams that can produce the tuple at any
+// position without stepping through the tuples that come before it.
+// The streams that Combinations, CombinationsWithReplacement,
+// Permutations, Product, Cartesian, and OpsPosits return implement
+// Unranker as do the streams that Range, Split, and Shuffle return from
+// them. Other streams such as Derangements do not.
+type Unranker interface {
+
+	// Unrank populates values with the tuple at the given zero based
+	// index. That is, Unrank(0, values) populates values with the first
+	// tuple that Next yields after a Reset. Unrank does not change the
+	// state of the stream. Unrank panics if index is negative or if index
+	// is greater than or equal to the number of tuples the stream emits.
+	// Caller must pass in a slice big enough to hold a tuple.
+	Unrank(index int64, values []int)
+
+	// UnrankBig works like Unrank but accepts an index of any size.
+	UnrankBig(index *big.Int, values []int)
+}
+
+// Ranker is implemented by streams that can find the position of any
+// tuple they emit. Ranker is the inverse of Unranker. The same streams
+// that implement Unranker implement Ranker.
+type Ranker interface {
+
+	// Rank returns the zero based index of the tuple in values. That is,
+	// Rank returns 0 for the first tuple that Next yields after a Reset.
+	// Rank returns an error wrapping ErrInvalidTuple if values is not a
+	// tuple that the stream emits and ErrOverflow if the index does not
+	// fit in an int64. Caller must pass in a slice big enough to hold
+	// a tuple.
+	Rank(values []int) (int64, error)
+
+	// RankBig works like Rank but returns an index of any size.
+	RankBig(values []int) (*big.Int, error)
+}
+
+func (c *combinations) Unrank(index int64, values []int) {
+	c.UnrankBig(big.NewInt(index), values)
+}
+
+func (c *combinations) UnrankBig(index *big.Int, values []int) {
+	remaining := checkUnrank(c, index, values)
+	c.order.lexIndex(remaining, c.Count())
+	prev := -1
+	for i := 0; i < c.k; i++ {
+		for j := prev + 1; ; j++ {
+			count := binomial(c.n-1-j, c.k-1-i)
+			if remaining.Cmp(count) < 0 {
+				values[i] = j
+				prev = j
+				break
+			}
+			remaining.Sub(remaining, count)
+		}
+	}
+	if c.order.complemented() {
+		complement(values[:c.k], c.n)
+	}
+}
+
+func (c *combinationsWithReplacement) Unrank(index int64, values []int) {
+	c.UnrankBig(big.NewInt(index), values)
+}
+
+func (c *combinationsWithReplacement) UnrankBig(
+	index *big.Int, values []int) {
+	remaining := checkUnrank(c, index, values)
+	c.order.lexIndex(remaining, c.Count())
+	prev := 0
+	for i := 0; i < c.k; i++ {
+		for j := prev; ; j++ {
+			count := multichoose(c.n-j, c.k-1-i)
+			if remaining.Cmp(count) < 0 {
+				values[i] = j
+				prev = j
+				break
+			}
+			remaining.Sub(remaining, count)
+		}
+	}
+	if c.order.complemented() {
+		complement(values[:c.k], c.n)
+	}
+}
+
+func (o *opsPosits) Unrank(index int64, values []int) {
+	o.UnrankBig(big.NewInt(index), values)
+}
+
+func (o *opsPosits) UnrankBig(index *big.Int, values []int) {
+	remaining := checkUnrank(o, index, values)
+	ways := opsPositsWays(o.k)
+	prev := 1
+	for i := 0; i < o.k; i++ {
+		for j := max(prev, i+1); ; j++ {
+			count := ways[i+1][j]
+			if remaining.Cmp(count) < 0 {
+				values[i] = j
+				prev = j
+				break
+			}
+			remaining.Sub(remaining, count)
+		}
+	}
+}
+
+func (p *permutations) Unrank(index int64, values []int) {
+	p.UnrankBig(big.NewInt(index), values)
+}
+
+func (p *permutations) UnrankBig(index *big.Int, values []int) {
+	remaining := checkUnrank(p, index, values)
+	available := make([]int, p.n)
+	for i := range available {
+		available[i] = i
+	}
+	var digit big.Int
+	for i := 0; i < p.k; i++ {
+
+		// The number of permutations that share the same first i+1 values.
+		weight := new(big.Int).MulRange(int64(p.n-p.k+1), int64(p.n-1-i))
+		digit.QuoRem(remaining, weight, remaining)
+		pos := int(digit.Int64())
+		values[i] = available[pos]
+		available = append(available[:pos], available[pos+1:]...)
+	}
+}
+
+func (c *cartesian) Unrank(index int64, values []int) {
+	c.UnrankBig(big.NewInt(index), values)
+}
+
+func (c *cartesian) UnrankBig(index *big.Int, values []int) {
+	remaining := checkUnrank(c, index, values)
+	unrankMixedRadix(remaining, c.sizes, values)
+}
+
+func (p *product) Unrank(index int64, values []int) {
+	p.UnrankBig(big.NewInt(index), values)
+}
+
+func (p *product) UnrankBig(index *big.Int, values []int) {
+	remaining := checkUnrank(p, index, values)
+	var digit big.Int
+	radix := big.NewInt(int64(p.n))
+	for i := p.k - 1; i >= 0; i-- {
+		remaining.QuoRem(remaining, radix, &digit)
+		values[i] = int(digit.Int64())
+	}
+}
+
+func (c *combinations) Rank(values []int) (int64, error) {
+	return rank64(c, values)
+}
+
+func (c *combinations) RankBig(values []int) (*big.Int, error) {
+	values = checkRank(c, values)
+	result := new(big.Int)
+	prev := -1
+	for i, value := range c.order.lexValues(values, c.n) {
+		if value <= prev || value >= c.n {
+			return nil, invalidTuple(values, "strictly increasing", c.n)
+		}
+		for j := prev + 1; j < value; j++ {
+			result.Add(result, binomial(c.n-1-j, c.k-1-i))
+		}
+		prev = value
+	}
+	c.order.lexIndex(result, c.Count())
+	return result, nil
+}
+
+func (c *combinationsWithReplacement) Rank(values []int) (int64, error) {
+	return rank64(c, values)
+}
+
+func (c *combinationsWithReplacement) RankBig(
+	values []int) (*big.Int, error) {
+	values = checkRank(c, values)
+	result := new(big.Int)
+	prev := 0
+	for i, value := range c.order.lexValues(values, c.n) {
+		if value < prev || value >= c.n {
+			return nil, invalidTuple(values, "non-decreasing", c.n)
+		}
+		for j := prev; j < value; j++ {
+			result.Add(result, multichoose(c.n-j, c.k-1-i))
+		}
+		prev = value
+	}
+	c.order.lexIndex(result, c.Count())
+	return result, nil
+}
+
+func (o *opsPosits) Rank(values []int) (int64, error) {
+	return rank64(o, values)
+}
+
+func (o *opsPosits) RankBig(values []int) (*big.Int, error) {
+	values = checkRank(o, values)
+	ways := opsPositsWays(o.k)
+	result := new(big.Int)
+	prev := 1
+	for i, value := range values {
+		if value < prev || value < i+1 || value > o.k {
+			return nil, fmt.Errorf(
+				"%w: %v must be non-decreasing with each value between "+
+					"its 1-based position and %d",
+				ErrInvalidTuple, values, o.k)
+		}
+		for j := max(prev, i+1); j < value; j++ {
+			result.Add(result, ways[i+1][j])
+		}
+		prev = value
+	}
+	return result, nil
+}
+
+func (p *permutations) Rank(values []int) (int64, error) {
+	return rank64(p, values)
+}
+
+func (p *permutations) RankBig(values []int) (*big.Int, error) {
+	values = checkRank(p, values)
+	used := newIntSet(p.n)
+	result := new(big.Int)
+	var digit big.Int
+	for i, value := range values {
+		if value < 0 || value >= p.n || used.Contains(value) {
+			return nil, invalidTuple(values, "distinct", p.n)
+		}
+
+		// The position of value among the values not used yet
+		pos := value
+		for j := used.Next(0); j != -1 && j < value; j = used.Next(j + 1) {
+			pos--
+		}
+		used.Add(value)
+		weight := new(big.Int).MulRange(int64(p.n-p.k+1), int64(p.n-1-i))
+		digit.SetInt64(int64(pos))
+		result.Add(result, weight.Mul(weight, &digit))
+	}
+	return result, nil
+}
+
+func (c *cartesian) Rank(values []int) (int64, error) {
+	return rank64(c, values)
+}
+
+func (c *cartesian) RankBig(values []int) (*big.Int, error) {
+	values = checkRank(c, values)
+	for i, value := range values {
+		if value < 0 || value >= c.sizes[i] {
+			return nil, fmt.Errorf(
+				"%w: %v must have each value less than %v",
+				ErrInvalidTuple, values, c.sizes)
+		}
+	}
+	return rankMixedRadix(values, c.sizes), nil
+}
+
+func (p *product) Rank(values []int) (int64, error) {
+	return rank64(p, values)
+}
+
+func (p *product) RankBig(values []int) (*big.Int, error) {
+	values = checkRank(p, values)
+	result := new(big.Int)
+	radix := big.NewInt(int64(p.n))
+	var digit big.Int
+	for _, value := range values {
+		if value < 0 || value >= p.n {
+			return nil, fmt.Errorf(
+				"%w: %v must have each value between 0 and %d",
+				ErrInvalidTuple, values, p.n-1)
+		}
+		result.Mul(result, radix)
+		result.Add(result, digit.SetInt64(int64(value)))
+	}
+	return result, nil
+}
+
+// Unrank populates values with the tuple at the given zero based index.
+// That is, Unrank(0, values) populates values with the first tuple that
+// Next yields after a Reset. Unrank does not change what Next yields.
+// Unrank panics if index is negative or if index is greater than or equal
+// to the number of tuples this TStream emits or if this TStream does not
+// support Unrank. Caller must pass in a slice big enough to hold a tuple.
+func (t *TStream[T]) Unrank(index int64, values []T) {
+	t.UnrankBig(big.NewInt(index), values)
+}
+
+// UnrankBig works like Unrank but accepts an index of any size.
+func (t *TStream[T]) UnrankBig(index *big.Int, values []T) {
+	if len(values) < len(t.indexes) {
+		panic(kSliceTooSmall)
+	}
+	if t.stream == nil {
+		panic(kIndexOutOfRange)
+	}
+	mustSupport[Unranker](t.stream, "Unrank").UnrankBig(index, t.indexes)
+	for i := range t.indexes {
+		values[i] = t.items[t.indexes[i]]
+	}
+}
+
+// checkUnrank panics if values is too small to hold a tuple from stream
+// or if index is out of range. Otherwise, checkUnrank returns a copy of
+// index that the caller is free to modify.
+func checkUnrank(
+	stream interface {
+		Stream
+		Counter
+	},
+	index *big.Int,
+	values []int) *big.Int {
+	if len(values) < stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if index.Sign() < 0 || index.Cmp(stream.Count()) >= 0 {
+		panic(kIndexOutOfRange)
+	}
+	return new(big.Int).Set(index)
+}
+
+// checkRank panics if values is too small to hold a tuple from stream.
+// Otherwise, checkRank returns values truncated to the tuple size of stream.
+func checkRank(stream Stream, values []int) []int {
+	size := stream.TupleSize()
+	if len(values) < size {
+		panic(kSliceTooSmall)
+	}
+	return values[:size]
+}
+
+func rank64(
+	ranker interface {
+		RankBig(values []int) (*big.Int, error)
+	},
+	values []int) (int64, error) {
+	result, err := ranker.RankBig(values)
+	if err != nil {
+		return 0, err
+	}
+	if !result.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return result.Int64(), nil
+}
+
+// invalidTuple returns an error explaining that values must be the given
+// kind of tuple with each value between 0 and n-1.
+func invalidTuple(values []int, kind string, n int) error {
+	return fmt.Errorf(
+		"%w: %v must be %s with each value between 0 and %d",
+		ErrInvalidTuple, values, kind, n-1)
+}
+
+// rankMixedRadix is the inverse of unrankMixedRadix.
+func rankMixedRadix(values []int, sizes []int) *big.Int {
+	result := new(big.Int)
+	var digit, radix big.Int
+	for i, value := range values {
+		radix.SetInt64(int64(sizes[i]))
+		result.Mul(result, &radix)
+		result.Add(result, digit.SetInt64(int64(value)))
+	}
+	return result
+}
+
+// unrankMixedRadix populates values with the digits of index where
+// sizes[i] is the radix of values[i] and the last value is the least
+// significant. unrankMixedRadix destroys index.
+func unrankMixedRadix(index *big.Int, sizes []int, values []int) {
+	var digit, radix big.Int
+	for i := len(sizes) - 1; i >= 0; i-- {
+		radix.SetInt64(int64(sizes[i]))
+		index.QuoRem(index, &radix, &digit)
+		values[i] = int(digit.Int64())
+	}
+}
+
+// opsPositsWays returns a table for OpsPosits(k) such that ways[i][j] is
+// the number of ways to fill in positions i through k-1 of a tuple given
+// that the value at position i must be at least j.
+func opsPositsWays(k int) [][]*big.Int {
+	ways := make([][]*big.Int, k+1)
+	ways[k] = make([]*big.Int, k+2)
+	for j := range ways[k] {
+		ways[k][j] = big.NewInt(1)
+	}
+	for i := k - 1; i >= 0; i-- {
+		ways[i] = make([]*big.Int, k+2)
+		ways[i][k+1] = new(big.Int)
+		for j := k; j >= 0; j-- {
+			if j < i+1 {
+				ways[i][j] = ways[i][j+1]
+			} else {
+				ways[i][j] = new(big.Int).Add(ways[i][j+1], ways[i+1][j])
+			}
+		}
+	}
+	return ways
+}
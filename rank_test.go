@@ -0,0 +1,158 @@
+package gocombinatorics_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnrank(t *testing.T) {
+	for n := 0; n < 6; n++ {
+		for k := 0; k < 6; k++ {
+			assertUnrank(t, gocombinatorics.Combinations(n, k))
+			assertUnrank(t, gocombinatorics.CombinationsWithReplacement(n, k))
+			assertUnrank(t, gocombinatorics.Permutations(n, k))
+			assertUnrank(t, gocombinatorics.Product(n, k))
+			assertUnrank(t, gocombinatorics.Cartesian(n, 2, k))
+		}
+		assertUnrank(t, gocombinatorics.OpsPosits(n))
+	}
+}
+
+func TestUnrankBig(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Permutations(30, 30)
+	unranker := stream.(gocombinatorics.Unranker)
+	last := stream.(gocombinatorics.Counter).Count()
+	last.Sub(last, big.NewInt(1))
+	values := make([]int, stream.TupleSize())
+	unranker.UnrankBig(last, values)
+	for i := range values {
+		assert.Equal(29-i, values[i])
+	}
+	assert.Panics(func() {
+		unranker.UnrankBig(new(big.Int).Add(last, big.NewInt(1)), values)
+	})
+	assert.Panics(func() { unranker.Unrank(-1, values) })
+	assert.Panics(func() { unranker.Unrank(0, nil) })
+}
+
+func TestRank(t *testing.T) {
+	for n := 0; n < 6; n++ {
+		for k := 0; k < 6; k++ {
+			assertRank(t, gocombinatorics.Combinations(n, k))
+			assertRank(t, gocombinatorics.CombinationsWithReplacement(n, k))
+			assertRank(t, gocombinatorics.Permutations(n, k))
+			assertRank(t, gocombinatorics.Product(n, k))
+			assertRank(t, gocombinatorics.Cartesian(n, 2, k))
+		}
+		assertRank(t, gocombinatorics.OpsPosits(n))
+	}
+}
+
+func TestRankInvalid(t *testing.T) {
+	assertInvalidTuple(t, gocombinatorics.Combinations(5, 3), 0, 2, 2)
+	assertInvalidTuple(t, gocombinatorics.Combinations(5, 3), 2, 1, 3)
+	assertInvalidTuple(t, gocombinatorics.Combinations(5, 3), 1, 2, 5)
+	assertInvalidTuple(t, gocombinatorics.Combinations(5, 6), 0, 1, 2, 3, 4, 5)
+	assertInvalidTuple(
+		t, gocombinatorics.CombinationsWithReplacement(3, 3), 1, 0, 2)
+	assertInvalidTuple(
+		t, gocombinatorics.CombinationsWithReplacement(3, 3), -1, 0, 2)
+	assertInvalidTuple(
+		t, gocombinatorics.CombinationsWithReplacement(3, 3), 0, 0, 3)
+	assertInvalidTuple(t, gocombinatorics.Permutations(4, 3), 1, 3, 1)
+	assertInvalidTuple(t, gocombinatorics.Permutations(4, 3), 1, 4, 0)
+	assertInvalidTuple(t, gocombinatorics.Product(3, 2), 3, 0)
+	assertInvalidTuple(t, gocombinatorics.Product(0, 1), 0)
+	assertInvalidTuple(t, gocombinatorics.Cartesian(3, 2), 2, 2)
+	assertInvalidTuple(t, gocombinatorics.OpsPosits(4), 1, 1, 3, 4)
+	assertInvalidTuple(t, gocombinatorics.OpsPosits(4), 1, 3, 2, 4)
+	assertInvalidTuple(t, gocombinatorics.OpsPosits(4), 1, 2, 3, 5)
+}
+
+func TestRankBig(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Product(10, 25)
+	ranker := stream.(gocombinatorics.Ranker)
+	values := make([]int, 25)
+	for i := range values {
+		values[i] = 9
+	}
+	rank, err := ranker.RankBig(values)
+	assert.NoError(err)
+	assert.Equal("9999999999999999999999999", rank.String())
+	_, err = ranker.Rank(values)
+	assert.Same(gocombinatorics.ErrOverflow, err)
+	assert.Panics(func() { ranker.Rank(nil) })
+}
+
+func TestTStreamUnrank(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TPermutations(
+		[]string{"alpha", "beta", "gamma", "delta"}, 2)
+	values := make([]string, stream.TupleSize())
+	stream.Unrank(7, values)
+	assert.Equal([]string{"gamma", "beta"}, values)
+	assert.Panics(func() { stream.Unrank(12, values) })
+	assert.Panics(func() { stream.Unrank(0, nil) })
+
+	// Unrank should not disturb Next
+	assertTStream(t, stream,
+		"alpha beta", "alpha gamma", "alpha delta",
+		"beta alpha", "beta gamma", "beta delta",
+		"gamma alpha", "gamma beta", "gamma delta",
+		"delta alpha", "delta beta", "delta gamma")
+	var zero gocombinatorics.TStream[string]
+	assert.Panics(func() { zero.Unrank(0, nil) })
+}
+
+// assertUnrank asserts that unranking each index of stream yields the
+// same tuple as calling Next.
+func assertUnrank(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	stream.Reset()
+	assert := assert.New(t)
+	unranker := stream.(gocombinatorics.Unranker)
+	values := make([]int, stream.TupleSize())
+	unranked := make([]int, stream.TupleSize())
+	var index int64
+	for stream.Next(values) {
+		unranker.Unrank(index, unranked)
+		if !assert.Equal(values, unranked) {
+			return
+		}
+		index++
+	}
+	assert.Panics(func() { unranker.Unrank(index, unranked) })
+}
+
+// assertRank asserts that ranking each tuple of stream yields the index
+// of that tuple.
+func assertRank(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	stream.Reset()
+	assert := assert.New(t)
+	ranker := stream.(gocombinatorics.Ranker)
+
+	// Extra values at the end should be ignored.
+	values := make([]int, stream.TupleSize()+1)
+	var index int64
+	for stream.Next(values) {
+		rank, err := ranker.Rank(values)
+		if !assert.NoError(err) || !assert.Equal(index, rank) {
+			return
+		}
+		index++
+	}
+}
+
+func assertInvalidTuple(
+	t *testing.T, stream gocombinatorics.Stream, values ...int) {
+	t.Helper()
+	_, err := stream.(gocombinatorics.Ranker).Rank(values)
+	assert.True(t, errors.Is(err, gocombinatorics.ErrInvalidTuple))
+}
@@ -0,0 +1,111 @@
+package gocombinatorics
+
+import (
+	"math/rand/v2"
+)
+
+// Reservoir returns m tuples chosen uniformly at random without
+// replacement from the remaining tuples of stream. If stream has fewer
+// than m remaining tuples, Reservoir returns all of them. Reservoir reads
+// stream just once using reservoir sampling, so stream need not implement
+// Counter or Unranker, and it leaves stream exhausted. The returned tuples
+// are copies in no particular order. Reservoir uses r as its source of
+// randomness or the default source in math/rand/v2 if r is nil.
+// Reservoir panics if m is negative.
+func Reservoir(stream Stream, m int, r *rand.Rand) [][]int {
+	return reservoir(m, stream.TupleSize(), stream.Next, r)
+}
+
+// Reservoir returns m tuples chosen uniformly at random without
+// replacement from the remaining tuples of this TStream. See the
+// Reservoir function for details. Reservoir leaves this TStream
+// exhausted.
+func (t *TStream[T]) Reservoir(m int, r *rand.Rand) [][]T {
+	return reservoir(m, t.TupleSize(), t.Next, r)
+}
+
+// Thin returns a Stream that yields each tuple of stream with probability
+// p independently of the other tuples. Thin uses r as its source of
+// randomness or the default source in math/rand/v2 if r is nil. Because r
+// cannot be rewound, Reset on the returned Stream goes back to the first
+// tuple of stream but lets a new random selection of tuples through. Thin
+// takes ownership of stream. Thin panics if p is not between 0 and 1.
+func Thin(stream Stream, p float64, r *rand.Rand) Stream {
+	checkProbability(p)
+	return &thinnedStream{
+		stream: stream,
+		p:      p,
+		r:      r,
+		values: make([]int, stream.TupleSize()),
+	}
+}
+
+// Thin returns a TStream that yields each tuple of this TStream with
+// probability p. See the Thin function for details. Thin takes ownership
+// of this TStream.
+func (t *TStream[T]) Thin(p float64, r *rand.Rand) *TStream[T] {
+	if t.stream == nil {
+		checkProbability(p)
+		return &TStream[T]{}
+	}
+	return newTStreamFrom(t.items, Thin(t.stream, p, r))
+}
+
+func reservoir[T any](
+	m, tupleSize int, next func(values []T) bool, r *rand.Rand) [][]T {
+	if m < 0 {
+		panic("m must be greater than or equal to 0")
+	}
+	result := make([][]T, 0, m)
+	values := make([]T, tupleSize)
+	var seen int64
+	for next(values) {
+		seen++
+		if len(result) < m {
+			result = append(result, append([]T(nil), values...))
+			continue
+		}
+
+		// Replace a random tuple in result with probability m/seen.
+		if j := randomInt64N(r, seen); j < int64(m) {
+			copy(result[j], values)
+		}
+	}
+	return result
+}
+
+type thinnedStream struct {
+	stream Stream
+	p      float64
+	r      *rand.Rand
+
+	// Holds tuples until we know whether to let them through
+	values []int
+}
+
+func (t *thinnedStream) TupleSize() int {
+	return t.stream.TupleSize()
+}
+
+func (t *thinnedStream) Next(values []int) bool {
+	if len(values) < len(t.values) {
+		panic(kSliceTooSmall)
+	}
+	for t.stream.Next(t.values) {
+		if randomFloat64(t.r) < t.p {
+			copy(values, t.values)
+			return true
+		}
+	}
+	return false
+}
+
+func (t *thinnedStream) Reset() {
+	t.stream.Reset()
+}
+
+func checkProbability(p float64) {
+	if !(p >= 0.0 && p <= 1.0) {
+		panic("p must be between 0 and 1")
+	}
+}
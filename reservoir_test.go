@@ -0,0 +1,109 @@
+package gocombinatorics_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservoir(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(1, 2))
+
+	// Derangements does not implement Unranker.
+	stream := gocombinatorics.Derangements(4)
+	valid := allTuples(stream)
+	stream.Reset()
+	sample := gocombinatorics.Reservoir(stream, 5, r)
+	assert.Len(sample, 5)
+	seen := make(map[string]bool)
+	for _, tuple := range sample {
+		s := asString(tuple)
+		assert.Contains(valid, s)
+		assert.False(seen[s])
+		seen[s] = true
+	}
+
+	// Reservoir leaves stream exhausted
+	assert.False(stream.Next(make([]int, 4)))
+
+	// Fewer tuples than m
+	stream.Reset()
+	sample = gocombinatorics.Reservoir(stream, 20, r)
+	assert.Len(sample, 9)
+	assert.Empty(gocombinatorics.Reservoir(stream, 20, r))
+	stream.Reset()
+	assert.Empty(gocombinatorics.Reservoir(stream, 0, r))
+	assert.Panics(func() { gocombinatorics.Reservoir(stream, -1, r) })
+	assert.Len(gocombinatorics.Reservoir(
+		gocombinatorics.Combinations(5, 2), 3, nil), 3)
+}
+
+func TestReservoirUniform(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(3, 4))
+	stream := gocombinatorics.Product(2, 3)
+	counts := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		stream.Reset()
+		for _, tuple := range gocombinatorics.Reservoir(stream, 2, r) {
+			counts[asString(tuple)]++
+		}
+	}
+
+	// Each of the 8 tuples should be chosen about 4000 * 2 / 8 times.
+	assert.Len(counts, 8)
+	for tuple, count := range counts {
+		assert.InDelta(1000, count, 150, tuple)
+	}
+}
+
+func TestThin(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(5, 6))
+	product := gocombinatorics.Product(10, 4)
+	stream := gocombinatorics.Thin(product, 0.25, r)
+	assert.Panics(func() { stream.Next(nil) })
+	assert.Equal(4, stream.TupleSize())
+	tuples := allTuples(stream)
+	assert.InDelta(2500, len(tuples), 200)
+	for i := 1; i < len(tuples); i++ {
+		assert.Less(tuples[i-1], tuples[i])
+	}
+
+	// Next leaves values unchanged at the end.
+	values := []int{7, 7, 7, 7}
+	assert.False(stream.Next(values))
+	assert.Equal([]int{7, 7, 7, 7}, values)
+
+	stream.Reset()
+	assert.InDelta(2500, len(allTuples(stream)), 200)
+	assertStream(t,
+		gocombinatorics.Thin(gocombinatorics.Product(2, 2), 1.0, r),
+		"0 0", "0 1", "1 0", "1 1")
+	assertStream(t,
+		gocombinatorics.Thin(gocombinatorics.Product(2, 2), 0.0, nil))
+	assert.Panics(func() { gocombinatorics.Thin(product, 1.5, r) })
+	assert.Panics(func() { gocombinatorics.Thin(product, -0.5, r) })
+}
+
+func TestTStreamReservoirAndThin(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(7, 8))
+	stream := gocombinatorics.TCombinations([]string{"a", "b", "c", "d"}, 2)
+	sample := stream.Reservoir(3, r)
+	assert.Len(sample, 3)
+	for _, tuple := range sample {
+		assert.Len(tuple, 2)
+		assert.Less(tuple[0], tuple[1])
+	}
+	stream.Reset()
+	thinned := stream.Thin(1.0, r)
+	assertTStream(t, thinned, "a b", "a c", "a d", "b c", "b d", "c d")
+	var zero gocombinatorics.TStream[string]
+	assert.Empty(zero.Reservoir(2, r))
+	assertTStream(t, zero.Thin(0.5, r))
+	assert.Panics(func() { zero.Thin(2.0, r) })
+}
@@ -0,0 +1,234 @@
+package gocombinatorics
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/rand/v2"
+)
+
+// randomAccessStream is a Stream that can produce any tuple directly.
+type randomAccessStream interface {
+	Stream
+	Counter
+	Unranker
+}
+
+// Random populates values with a tuple chosen uniformly at random from
+// all the tuples of stream. Random uses r as its source of randomness or
+// the default source in math/rand/v2 if r is nil. Random does not change
+// stream.
+//
+// stream must implement Counter and Unranker. The streams that
+// Combinations, CombinationsWithReplacement, Permutations, Product,
+// Cartesian, and OpsPosits return do as do the streams that Range, Split,
+// and Shuffle return from them. Streams such as Derangements and
+// SetPartitions do not. Random panics if stream does not support it or if
+// stream has no tuples. Caller must pass in a slice big enough to hold a
+// tuple.
+func Random(stream Stream, r *rand.Rand, values []int) {
+	s := mustSupport[randomAccessStream](stream, "Random")
+	s.UnrankBig(randomIndex(r, s.Count()), values)
+}
+
+// Sample returns a Stream that yields count tuples chosen uniformly at
+// random from the tuples of stream with replacement so that the same
+// tuple may be yielded more than once. The returned Stream uses r as its
+// source of randomness or the default source in math/rand/v2 if r is
+// nil. Because r cannot be rewound, Reset on the returned Stream starts a
+// new sample of count tuples rather than repeating the previous one. The
+// returned Stream implements Counter. Sample does not change stream.
+//
+// stream must implement Counter and Unranker as described in Random.
+// Sample panics if stream does not support it, if count is negative, or
+// if count is positive and stream has no tuples.
+func Sample(stream Stream, count int, r *rand.Rand) Stream {
+	return newSampleStream(stream, count, r, true)
+}
+
+// SampleWithoutReplacement works like Sample except that it never yields
+// the same tuple twice before a Reset. SampleWithoutReplacement panics if
+// stream does not support it, if count is negative, or if count is
+// greater than the number of tuples in stream.
+func SampleWithoutReplacement(stream Stream, count int, r *rand.Rand) Stream {
+	return newSampleStream(stream, count, r, false)
+}
+
+// Random populates values with a tuple chosen uniformly at random from all
+// the tuples of this TStream. Random uses r as its source of randomness or
+// the default source in math/rand/v2 if r is nil. Random does not change
+// this TStream. Random panics if this TStream does not support it or if
+// this TStream has no tuples. Caller must pass in a slice big enough to
+// hold a tuple.
+func (t *TStream[T]) Random(r *rand.Rand, values []T) {
+	if len(values) < len(t.indexes) {
+		panic(kSliceTooSmall)
+	}
+	if t.stream == nil {
+		panic(kIndexOutOfRange)
+	}
+	Random(t.stream, r, t.indexes)
+	for i := range t.indexes {
+		values[i] = t.items[t.indexes[i]]
+	}
+}
+
+// Sample returns a TStream that yields count tuples chosen uniformly at
+// random from the tuples of this TStream with replacement. See the Sample
+// function for details. Sample does not change this TStream.
+func (t *TStream[T]) Sample(count int, r *rand.Rand) *TStream[T] {
+	return t.sample(count, r, true)
+}
+
+// SampleWithoutReplacement returns a TStream that yields count distinct
+// tuples chosen uniformly at random from the tuples of this TStream. See
+// the SampleWithoutReplacement function for details.
+// SampleWithoutReplacement does not change this TStream.
+func (t *TStream[T]) SampleWithoutReplacement(
+	count int, r *rand.Rand) *TStream[T] {
+	return t.sample(count, r, false)
+}
+
+func (t *TStream[T]) sample(
+	count int, r *rand.Rand, replace bool) *TStream[T] {
+	if t.stream == nil {
+		if count < 0 {
+			panic("count must be greater than or equal to 0")
+		}
+		if count > 0 {
+			panic(kIndexOutOfRange)
+		}
+		return &TStream[T]{}
+	}
+	return newTStreamFrom(
+		t.items, newSampleStream(t.stream, count, r, replace))
+}
+
+func newSampleStream(
+	stream Stream, count int, r *rand.Rand, replace bool) *sampleStream {
+	if count < 0 {
+		panic("count must be greater than or equal to 0")
+	}
+	method := "Sample"
+	if !replace {
+		method = "SampleWithoutReplacement"
+	}
+	s := mustSupport[randomAccessStream](stream, method)
+	total := s.Count()
+	if (replace && count > 0 && total.Sign() == 0) ||
+		(!replace && total.Cmp(big.NewInt(int64(count))) < 0) {
+		panic(kIndexOutOfRange)
+	}
+	result := &sampleStream{
+		stream:  s,
+		total:   total,
+		count:   count,
+		r:       r,
+		replace: replace,
+	}
+	result.Reset()
+	return result
+}
+
+type sampleStream struct {
+	stream    randomAccessStream
+	total     *big.Int
+	count     int
+	r         *rand.Rand
+	replace   bool
+	remaining int
+
+	// The indexes already yielded when sampling without replacement
+	chosen map[string]struct{}
+}
+
+func (s *sampleStream) TupleSize() int {
+	return s.stream.TupleSize()
+}
+
+func (s *sampleStream) Next(values []int) bool {
+	if len(values) < s.stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if s.remaining == 0 {
+		return false
+	}
+	index := randomIndex(s.r, s.total)
+	if !s.replace {
+
+		// Keep drawing until we get an index we haven't seen.
+		for {
+			key := index.String()
+			if _, ok := s.chosen[key]; !ok {
+				s.chosen[key] = struct{}{}
+				break
+			}
+			index = randomIndex(s.r, s.total)
+		}
+	}
+	s.stream.UnrankBig(index, values)
+	s.remaining--
+	return true
+}
+
+func (s *sampleStream) Reset() {
+	s.remaining = s.count
+	if !s.replace {
+		s.chosen = make(map[string]struct{}, s.count)
+	}
+}
+
+func (s *sampleStream) Count() *big.Int {
+	return big.NewInt(int64(s.count))
+}
+
+func (s *sampleStream) Count64() (int64, bool) {
+	return count64(s)
+}
+
+// randomIndex returns a uniformly random index from 0 up to but not
+// including n using r or the default source if r is nil. randomIndex
+// panics if n is not positive.
+func randomIndex(r *rand.Rand, n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		panic(kIndexOutOfRange)
+	}
+	if n.IsInt64() {
+		return big.NewInt(randomInt64N(r, n.Int64()))
+	}
+
+	// Draw random bits until we get a number less than n.
+	bits := n.BitLen()
+	buf := make([]byte, (bits+63)/64*8)
+	result := new(big.Int)
+	for {
+		for i := 0; i < len(buf); i += 8 {
+			binary.BigEndian.PutUint64(buf[i:], randomUint64(r))
+		}
+		result.SetBytes(buf)
+		result.Rsh(result, uint(len(buf)*8-bits))
+		if result.Cmp(n) < 0 {
+			return result
+		}
+	}
+}
+
+func randomUint64(r *rand.Rand) uint64 {
+	if r == nil {
+		return rand.Uint64()
+	}
+	return r.Uint64()
+}
+
+func randomInt64N(r *rand.Rand, n int64) int64 {
+	if r == nil {
+		return rand.Int64N(n)
+	}
+	return r.Int64N(n)
+}
+
+func randomFloat64(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
@@ -0,0 +1,163 @@
+package gocombinatorics_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(1, 2))
+	stream := gocombinatorics.Combinations(4, 2)
+	values := make([]int, 2)
+	counts := make(map[string]int)
+	for i := 0; i < 6000; i++ {
+		gocombinatorics.Random(stream, r, values)
+		counts[asString(values)]++
+	}
+	assert.Len(counts, 6)
+	for tuple, count := range counts {
+		assert.InDelta(1000, count, 150, tuple)
+	}
+
+	// Random does not change stream
+	assertNext(t, stream, "0 1")
+
+	assert.Panics(func() { gocombinatorics.Random(stream, r, nil) })
+	assert.Panics(func() {
+		gocombinatorics.Random(gocombinatorics.Combinations(2, 3), r, values)
+	})
+	gocombinatorics.Random(stream, nil, values)
+	assert.True(values[0] < values[1])
+	assert.PanicsWithValue("This stream does not support Random.", func() {
+		gocombinatorics.Random(gocombinatorics.Derangements(3), r, values)
+	})
+	assert.PanicsWithValue("This stream does not support Sample.", func() {
+		gocombinatorics.Sample(gocombinatorics.SetPartitions(3), 1, r)
+	})
+}
+
+func TestRandomSameSeed(t *testing.T) {
+	stream := gocombinatorics.Combinations(60, 8)
+	assert.Equal(t,
+		randomTuples(stream, rand.New(rand.NewPCG(3, 4)), 5),
+		randomTuples(stream, rand.New(rand.NewPCG(3, 4)), 5))
+}
+
+func TestRandomBig(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(5, 6))
+
+	// 10^30 tuples is too many for an int64.
+	stream := gocombinatorics.Product(10, 30)
+	values := make([]int, 30)
+	seen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		gocombinatorics.Random(stream, r, values)
+		seen[values[0]] = true
+	}
+	assert.Len(seen, 10)
+}
+
+func TestSample(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(7, 8))
+	stream := gocombinatorics.Sample(gocombinatorics.OpsPosits(3), 20, r)
+	assert.Panics(func() { stream.Next(nil) })
+	assertCount(t, stream)
+	stream.Reset()
+	tuples := allTuples(stream)
+	assert.Len(tuples, 20)
+	valid := allTuples(gocombinatorics.OpsPosits(3))
+	for _, tuple := range tuples {
+		assert.Contains(valid, tuple)
+	}
+	stream.Reset()
+	assert.Len(allTuples(stream), 20)
+	assertStream(t, gocombinatorics.Sample(gocombinatorics.Product(2, 0), 2, r),
+		"", "")
+	assertStream(t, gocombinatorics.Sample(gocombinatorics.Product(0, 2), 0, r))
+	assert.Panics(func() {
+		gocombinatorics.Sample(gocombinatorics.Product(0, 2), 1, r)
+	})
+	assert.Panics(func() {
+		gocombinatorics.Sample(gocombinatorics.Product(2, 2), -1, r)
+	})
+}
+
+func TestSampleWithoutReplacement(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(9, 10))
+	cartesian := gocombinatorics.Cartesian(3, 2, 2)
+	stream := gocombinatorics.SampleWithoutReplacement(cartesian, 12, r)
+	tuples := allTuples(stream)
+	assert.ElementsMatch(allTuples(cartesian), tuples)
+	stream.Reset()
+	assert.ElementsMatch(tuples, allTuples(stream))
+	stream = gocombinatorics.SampleWithoutReplacement(
+		gocombinatorics.Combinations(60, 8), 1000, r)
+	seen := make(map[string]bool)
+	for _, tuple := range allTuples(stream) {
+		assert.False(seen[tuple])
+		seen[tuple] = true
+	}
+	assert.Len(seen, 1000)
+	assert.Panics(func() {
+		gocombinatorics.SampleWithoutReplacement(cartesian, 13, r)
+	})
+	assert.Panics(func() {
+		gocombinatorics.SampleWithoutReplacement(cartesian, -1, r)
+	})
+}
+
+func TestTStreamSample(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewPCG(11, 12))
+	stream := gocombinatorics.TPermutations([]string{"a", "b", "c"}, 2)
+	values := make([]string, 2)
+	stream.Random(r, values)
+	assert.NotEqual(values[0], values[1])
+	assert.Panics(func() { stream.Random(r, nil) })
+	sample := stream.SampleWithoutReplacement(6, r)
+	assert.Equal("6", sample.Count().String())
+	var actual []string
+	for sample.Next(values) {
+		actual = append(actual, values[0]+values[1])
+	}
+	assert.ElementsMatch(
+		[]string{"ab", "ac", "ba", "bc", "ca", "cb"}, actual)
+	sample = stream.Sample(10, r)
+	count := 0
+	for sample.Next(values) {
+		count++
+	}
+	assert.Equal(10, count)
+
+	// Sample does not change stream
+	assertTStream(t, stream, "a b", "a c", "b a", "b c", "c a", "c b")
+
+	var zero gocombinatorics.TStream[string]
+	assert.Panics(func() { zero.Random(r, values) })
+	assertTStream(t, zero.Sample(0, r))
+	assert.Panics(func() { zero.Sample(1, r) })
+
+	derangements := gocombinatorics.TDerangements([]string{"a", "b", "c"})
+	assert.PanicsWithValue("This stream does not support Random.", func() {
+		derangements.Random(r, make([]string, 3))
+	})
+}
+
+// randomTuples returns count random tuples from stream.
+func randomTuples(
+	stream gocombinatorics.Stream, r *rand.Rand, count int) []string {
+	result := []string{}
+	values := make([]int, stream.TupleSize())
+	for i := 0; i < count; i++ {
+		gocombinatorics.Random(stream, r, values)
+		result = append(result, asString(values))
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// Seeker is implemented by streams that can be repositioned at an
+// arbitrary tuple without stepping through the tuples that come before it.
+// The same streams that implement Unranker implement Seeker.
+type Seeker interface {
+
+	// SkipTo positions the stream so that the next call to Next yields the
+	// tuple at the given zero based index. Skipping to an index equal to
+	// the number of tuples positions the stream at the end so that Next
+	// returns false. SkipTo panics if index is negative or if index is
+	// greater than the number of tuples the stream emits.
+	SkipTo(index int64)
+
+	// SkipToBig works like SkipTo but accepts an index of any size.
+	SkipToBig(index *big.Int)
+
+	// SkipToTuple positions the stream so that the next call to Next
+	// yields the tuple in values. If values is not a tuple the stream emits,
+	// SkipToTuple returns an error wrapping ErrInvalidTuple and leaves the
+	// stream unchanged. Caller must pass in a slice big enough to hold a
+	// tuple.
+	SkipToTuple(values []int) error
+}
+
+func (c *combinations) SkipTo(index int64) {
+	c.SkipToBig(big.NewInt(index))
+}
+
+func (c *combinations) SkipToBig(index *big.Int) {
+	c.done = seek(c, index, c.values)
+}
+
+func (c *combinations) SkipToTuple(values []int) error {
+	if _, err := c.RankBig(values); err != nil {
+		return err
+	}
+	copy(c.values, values)
+	c.done = false
+	return nil
+}
+
+func (c *combinationsWithReplacement) SkipTo(index int64) {
+	c.SkipToBig(big.NewInt(index))
+}
+
+func (c *combinationsWithReplacement) SkipToBig(index *big.Int) {
+	c.done = seek(c, index, c.values)
+}
+
+func (c *combinationsWithReplacement) SkipToTuple(values []int) error {
+	if _, err := c.RankBig(values); err != nil {
+		return err
+	}
+	copy(c.values, values)
+	c.done = false
+	return nil
+}
+
+func (o *opsPosits) SkipTo(index int64) {
+	o.SkipToBig(big.NewInt(index))
+}
+
+func (o *opsPosits) SkipToBig(index *big.Int) {
+	o.done = seek(o, index, o.values)
+}
+
+func (o *opsPosits) SkipToTuple(values []int) error {
+	if _, err := o.RankBig(values); err != nil {
+		return err
+	}
+	copy(o.values, values)
+	o.done = false
+	return nil
+}
+
+func (p *permutations) SkipTo(index int64) {
+	p.SkipToBig(big.NewInt(index))
+}
+
+func (p *permutations) SkipToBig(index *big.Int) {
+	p.done = seek(p, index, p.values)
+	if !p.done {
+		p.syncUnused()
+	}
+}
+
+func (p *permutations) SkipToTuple(values []int) error {
+	if _, err := p.RankBig(values); err != nil {
+		return err
+	}
+	copy(p.values, values)
+	p.done = false
+	p.syncUnused()
+	return nil
+}
+
+// syncUnused makes p.unused contain everything except the values
+// preceding the last value in the current tuple.
+func (p *permutations) syncUnused() {
+	if p.k == 0 {
+		return
+	}
+	for i := 0; i < p.n; i++ {
+		p.unused.Add(i)
+	}
+	for i := 0; i < p.k-1; i++ {
+		p.unused.Remove(p.values[i])
+	}
+}
+
+func (c *cartesian) SkipTo(index int64) {
+	c.SkipToBig(big.NewInt(index))
+}
+
+func (c *cartesian) SkipToBig(index *big.Int) {
+	c.done = seek(c, index, c.values)
+}
+
+func (c *cartesian) SkipToTuple(values []int) error {
+	if _, err := c.RankBig(values); err != nil {
+		return err
+	}
+	copy(c.values, values)
+	c.done = false
+	return nil
+}
+
+func (p *product) SkipTo(index int64) {
+	p.SkipToBig(big.NewInt(index))
+}
+
+func (p *product) SkipToBig(index *big.Int) {
+	p.done = seek(p, index, p.values)
+}
+
+func (p *product) SkipToTuple(values []int) error {
+	if _, err := p.RankBig(values); err != nil {
+		return err
+	}
+	copy(p.values, values)
+	p.done = false
+	return nil
+}
+
+// SkipTo positions this TStream so that the next call to Next yields the
+// tuple at the given zero based index. Skipping to an index equal to the
+// number of tuples positions this TStream at the end so that Next returns
+// false. SkipTo panics if index is negative, if index is greater than
+// the number of tuples this TStream emits, or if this TStream does not
+// support SkipTo.
+func (t *TStream[T]) SkipTo(index int64) {
+	t.SkipToBig(big.NewInt(index))
+}
+
+// SkipToBig works like SkipTo but accepts an index of any size.
+func (t *TStream[T]) SkipToBig(index *big.Int) {
+	if t.stream == nil {
+		if index.Sign() != 0 {
+			panic(kIndexOutOfRange)
+		}
+		return
+	}
+	mustSupport[Seeker](t.stream, "SkipTo").SkipToBig(index)
+}
+
+// seek populates values with the tuple at index and returns false. If
+// index equals the number of tuples in stream, seek leaves values alone
+// and returns true meaning the stream is done. seek panics if index is
+// out of range.
+func seek(
+	stream interface {
+		Counter
+		Unranker
+	},
+	index *big.Int,
+	values []int) bool {
+	if index.Cmp(stream.Count()) == 0 {
+		return true
+	}
+	stream.UnrankBig(index, values)
+	return false
+}
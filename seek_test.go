@@ -0,0 +1,128 @@
+package gocombinatorics_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeek(t *testing.T) {
+	for n := 0; n < 5; n++ {
+		for k := 0; k < 5; k++ {
+			assertSeek(t, gocombinatorics.Combinations(n, k))
+			assertSeek(t, gocombinatorics.CombinationsWithReplacement(n, k))
+			assertSeek(t, gocombinatorics.Permutations(n, k))
+			assertSeek(t, gocombinatorics.Product(n, k))
+			assertSeek(t, gocombinatorics.Cartesian(n, 2, k))
+		}
+		assertSeek(t, gocombinatorics.OpsPosits(n))
+	}
+}
+
+func TestSkipToTuple(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Permutations(4, 3)
+	seeker := stream.(gocombinatorics.Seeker)
+	values := make([]int, 3)
+	stream.Next(values)
+	assert.NoError(seeker.SkipToTuple([]int{2, 3, 1}))
+	assertNext(t, stream, "2 3 1", "3 0 1", "3 0 2")
+	err := seeker.SkipToTuple([]int{2, 2, 1})
+	assert.True(errors.Is(err, gocombinatorics.ErrInvalidTuple))
+
+	// Failed SkipToTuple leaves stream unchanged
+	assertNext(t, stream, "3 1 0")
+	assert.Panics(func() { seeker.SkipToTuple(nil) })
+}
+
+func TestSeekPanics(t *testing.T) {
+	assert := assert.New(t)
+	seeker := gocombinatorics.Combinations(5, 3).(gocombinatorics.Seeker)
+	assert.Panics(func() { seeker.SkipTo(-1) })
+	assert.Panics(func() { seeker.SkipTo(11) })
+	seeker.SkipTo(10)
+}
+
+func TestTStreamSeek(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TProduct([]string{"blue", "green", "red"}, 2)
+	stream.SkipTo(5)
+	values := make([]string, stream.TupleSize())
+	assert.True(stream.Next(values))
+	assert.Equal([]string{"green", "red"}, values)
+	stream.SkipTo(9)
+	assert.False(stream.Next(values))
+	assert.Panics(func() { stream.SkipTo(10) })
+	var zero gocombinatorics.TStream[string]
+	zero.SkipTo(0)
+	assert.False(zero.Next(nil))
+	assert.Panics(func() { zero.SkipTo(1) })
+}
+
+// assertSeek asserts that seeking to each index of stream and then
+// calling Next yields the remaining tuples of stream.
+func assertSeek(t *testing.T, stream gocombinatorics.Stream) {
+	t.Helper()
+	stream.Reset()
+	seeker := stream.(gocombinatorics.Seeker)
+	expected := allTuples(stream)
+	for i := len(expected); i >= 0; i-- {
+		seeker.SkipTo(int64(i))
+		if !assert.Equal(t, expected[i:], allTuples(stream)) {
+			return
+		}
+	}
+	for i := range expected {
+		values := parseTuple(expected[i])
+		if !assert.NoError(t, seeker.SkipToTuple(values)) {
+			return
+		}
+		if !assert.Equal(t, expected[i:], allTuples(stream)) {
+			return
+		}
+	}
+	stream.Reset()
+	assert.Equal(t, expected, allTuples(stream))
+}
+
+// assertNext asserts that the next tuples stream yields are results.
+func assertNext(
+	t *testing.T, stream gocombinatorics.Stream, results ...string) {
+	t.Helper()
+	values := make([]int, stream.TupleSize())
+	for _, result := range results {
+		if !assert.True(t, stream.Next(values)) {
+			return
+		}
+		if !assert.Equal(t, result, asString(values)) {
+			return
+		}
+	}
+}
+
+// allTuples returns the remaining tuples in stream as strings.
+func allTuples(stream gocombinatorics.Stream) []string {
+	result := []string{}
+	values := make([]int, stream.TupleSize())
+	for stream.Next(values) {
+		result = append(result, asString(values))
+	}
+	return result
+}
+
+// parseTuple is the inverse of asString.
+func parseTuple(s string) []int {
+	result := []int{}
+	for _, field := range strings.Fields(s) {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, value)
+	}
+	return result
+}
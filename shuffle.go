@@ -0,0 +1,226 @@
+package gocombinatorics
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+const (
+	kShuffleRounds = 6
+)
+
+// Shuffle returns a Stream that yields every tuple of stream exactly once
+// but in a pseudorandom order determined by seed. Shuffle needs only a
+// constant amount of memory no matter how many tuples stream has because
+// it computes the order on the fly by permuting the indexes of the tuples
+// with a keyed Feistel network. The same seed always gives the same
+// order, so Reset on the returned Stream replays the same order.
+//
+// The returned Stream implements Counter, Unranker, Ranker, and Seeker
+// with indexes that refer to the shuffled order, and it works with Range
+// and Split. Shuffle does not change stream.
+//
+// stream must implement Counter, Unranker, Ranker, and Seeker. The
+// streams that Combinations, CombinationsWithReplacement, Permutations,
+// Product, Cartesian, and OpsPosits return do as do the streams that
+// Range and Split return from them. Shuffle panics if stream does not
+// support it.
+func Shuffle(stream Stream, seed uint64) Stream {
+	s := mustSupport[rankedStream](stream, "Shuffle")
+	count := s.Count()
+
+	// The Feistel network works on an even number of bits.
+	halfBits := uint((count.BitLen() + 1) / 2)
+	if halfBits == 0 {
+		halfBits = 1
+	}
+	result := &shuffleStream{
+		stream:   s,
+		count:    count,
+		halfBits: halfBits,
+		mask:     new(big.Int).Sub(new(big.Int).Lsh(bigOne, halfBits), bigOne),
+		next:     new(big.Int),
+	}
+	for i := range result.keys {
+		seed += 0x9e3779b97f4a7c15
+		result.keys[i] = mix64(seed)
+	}
+	return result
+}
+
+// Shuffle returns a TStream that yields every tuple of this TStream
+// exactly once but in a pseudorandom order determined by seed. See the
+// Shuffle function for details. Shuffle does not change this TStream.
+func (t *TStream[T]) Shuffle(seed uint64) *TStream[T] {
+	if t.stream == nil {
+		return &TStream[T]{}
+	}
+	return newTStreamFrom(t.items, Shuffle(t.stream, seed))
+}
+
+type shuffleStream struct {
+	stream   rankedStream
+	count    *big.Int
+	halfBits uint
+
+	// mask has the lowest halfBits bits set.
+	mask *big.Int
+
+	keys [kShuffleRounds]uint64
+
+	// The index in shuffled order of the tuple Next yields next
+	next *big.Int
+}
+
+func (s *shuffleStream) TupleSize() int {
+	return s.stream.TupleSize()
+}
+
+func (s *shuffleStream) Next(values []int) bool {
+	if len(values) < s.stream.TupleSize() {
+		panic(kSliceTooSmall)
+	}
+	if s.next.Cmp(s.count) == 0 {
+		return false
+	}
+	s.stream.UnrankBig(s.permute(s.next), values)
+	s.next.Add(s.next, bigOne)
+	return true
+}
+
+func (s *shuffleStream) Reset() {
+	s.next.SetInt64(0)
+}
+
+func (s *shuffleStream) Count() *big.Int {
+	return new(big.Int).Set(s.count)
+}
+
+func (s *shuffleStream) Count64() (int64, bool) {
+	return count64(s)
+}
+
+func (s *shuffleStream) Unrank(index int64, values []int) {
+	s.UnrankBig(big.NewInt(index), values)
+}
+
+func (s *shuffleStream) UnrankBig(index *big.Int, values []int) {
+	checkUnrank(s, index, values)
+	s.stream.UnrankBig(s.permute(index), values)
+}
+
+func (s *shuffleStream) Rank(values []int) (int64, error) {
+	return rank64(s, values)
+}
+
+func (s *shuffleStream) RankBig(values []int) (*big.Int, error) {
+	index, err := s.stream.RankBig(values)
+	if err != nil {
+		return nil, err
+	}
+	return s.unpermute(index), nil
+}
+
+func (s *shuffleStream) SkipTo(index int64) {
+	s.SkipToBig(big.NewInt(index))
+}
+
+func (s *shuffleStream) SkipToBig(index *big.Int) {
+	if index.Sign() < 0 || index.Cmp(s.count) > 0 {
+		panic(kIndexOutOfRange)
+	}
+	s.next.Set(index)
+}
+
+func (s *shuffleStream) SkipToTuple(values []int) error {
+	index, err := s.RankBig(values)
+	if err != nil {
+		return err
+	}
+	s.next = index
+	return nil
+}
+
+func (s *shuffleStream) position() *big.Int {
+	return new(big.Int).Set(s.next)
+}
+
+func (s *shuffleStream) clone() splittableStream {
+	result := *s
+	result.next = new(big.Int).Set(s.next)
+	return &result
+}
+
+// permute maps an index in shuffled order to an index of the underlying
+// stream. permute walks the cycle of the Feistel network until it lands
+// on an index less than s.count. permute does not change index.
+func (s *shuffleStream) permute(index *big.Int) *big.Int {
+	x := index
+	for {
+		x = s.feistel(x)
+		if x.Cmp(s.count) < 0 {
+			return x
+		}
+	}
+}
+
+// unpermute is the inverse of permute.
+func (s *shuffleStream) unpermute(index *big.Int) *big.Int {
+	x := index
+	for {
+		x = s.feistelInverse(x)
+		if x.Cmp(s.count) < 0 {
+			return x
+		}
+	}
+}
+
+func (s *shuffleStream) feistel(x *big.Int) *big.Int {
+	left := new(big.Int).Rsh(x, s.halfBits)
+	right := new(big.Int).And(x, s.mask)
+	for _, key := range s.keys {
+		left.Xor(left, s.round(right, key))
+		left, right = right, left
+	}
+	return left.Lsh(left, s.halfBits).Or(left, right)
+}
+
+func (s *shuffleStream) feistelInverse(x *big.Int) *big.Int {
+	left := new(big.Int).Rsh(x, s.halfBits)
+	right := new(big.Int).And(x, s.mask)
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		right.Xor(right, s.round(left, s.keys[i]))
+		left, right = right, left
+	}
+	return left.Lsh(left, s.halfBits).Or(left, right)
+}
+
+// round is the round function of the Feistel network. round hashes x,
+// which has at most halfBits bits, together with key into halfBits
+// pseudorandom bits. round does not change s, so Unrank and Rank are safe
+// to call on clones that share the same underlying stream.
+func (s *shuffleStream) round(x *big.Int, key uint64) *big.Int {
+	buf := make([]byte, (s.halfBits+63)/64*8)
+	x.FillBytes(buf)
+	hash := key
+	for i := 0; i < len(buf); i += 8 {
+		hash = mix64(hash ^ binary.BigEndian.Uint64(buf[i:]))
+	}
+	for i := 0; i < len(buf); i += 8 {
+		hash = mix64(hash + 0x9e3779b97f4a7c15)
+		binary.BigEndian.PutUint64(buf[i:], hash)
+	}
+	result := new(big.Int).SetBytes(buf)
+	return result.And(result, s.mask)
+}
+
+// mix64 is the finalizer of the SplitMix64 generator. It scrambles the
+// bits of x.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
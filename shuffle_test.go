@@ -0,0 +1,145 @@
+package gocombinatorics_test
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	assert := assert.New(t)
+	combos := gocombinatorics.Combinations(8, 3)
+	expected := allTuples(combos)
+	stream := gocombinatorics.Shuffle(combos, 42)
+	assert.Panics(func() { stream.Next(nil) })
+	tuples := allTuples(stream)
+	assert.ElementsMatch(expected, tuples)
+	assert.NotEqual(expected, tuples)
+
+	// Reset replays the same order
+	stream.Reset()
+	assert.Equal(tuples, allTuples(stream))
+
+	// The same seed gives the same order; a different seed doesn't.
+	assert.Equal(
+		tuples, allTuples(gocombinatorics.Shuffle(combos, 42)))
+	assert.NotEqual(
+		tuples, allTuples(gocombinatorics.Shuffle(combos, 43)))
+
+	assertCount(t, stream)
+	assertUnrank(t, stream)
+	assertRank(t, stream)
+	assertSeek(t, stream)
+	stream.Reset()
+	assertSplit(t, stream, 4)
+	assertInvalidTuple(t, stream, 3, 2, 1)
+}
+
+func TestShuffleSmall(t *testing.T) {
+	for n := 0; n < 6; n++ {
+		for k := 0; k < 4; k++ {
+			product := gocombinatorics.Product(n, k)
+			assert.ElementsMatch(t,
+				allTuples(product),
+				allTuples(gocombinatorics.Shuffle(product, 7)))
+		}
+	}
+}
+
+func TestShuffleLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	// C(60, 8) is about 2.5 billion
+	stream := gocombinatorics.Shuffle(gocombinatorics.Combinations(60, 8), 1)
+	seen := make(map[string]bool)
+	values := make([]int, 8)
+	for i := 0; i < 10000; i++ {
+		assert.True(stream.Next(values))
+		seen[asString(values)] = true
+	}
+	assert.Len(seen, 10000)
+	ranker := stream.(gocombinatorics.Ranker)
+	rank, err := ranker.Rank(values)
+	assert.NoError(err)
+	assert.Equal(int64(9999), rank)
+}
+
+func TestShuffleBig(t *testing.T) {
+	assert := assert.New(t)
+
+	// 10^30 tuples do not fit in an int64.
+	stream := gocombinatorics.Shuffle(gocombinatorics.Product(10, 30), 3)
+	assert.Equal(
+		"1000000000000000000000000000000",
+		stream.(gocombinatorics.Counter).Count().String())
+	seen := make(map[string]bool)
+	values := make([]int, 30)
+	for i := 0; i < 1000; i++ {
+		assert.True(stream.Next(values))
+		seen[asString(values)] = true
+	}
+	assert.Len(seen, 1000)
+	ranker := stream.(gocombinatorics.Ranker)
+	rank, err := ranker.Rank(values)
+	assert.NoError(err)
+	assert.Equal(int64(999), rank)
+
+	index, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	stream.(gocombinatorics.Unranker).UnrankBig(index, values)
+	bigRank, err := ranker.RankBig(values)
+	assert.NoError(err)
+	assert.Equal(index, bigRank)
+	_, err = ranker.Rank(values)
+	assert.ErrorIs(err, gocombinatorics.ErrOverflow)
+	stream.(gocombinatorics.Seeker).SkipToBig(index)
+	next := make([]int, 30)
+	assert.True(stream.Next(next))
+	assert.Equal(values, next)
+}
+
+func TestShuffleSplitParallel(t *testing.T) {
+	stream := gocombinatorics.Shuffle(
+		gocombinatorics.Shuffle(gocombinatorics.Product(10, 4), 1), 2)
+	expected := allTuples(stream)
+	stream.Reset()
+	parts := gocombinatorics.Split(stream, 4)
+	results := make([][]string, len(parts))
+	var wg sync.WaitGroup
+	for i, part := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values := make([]int, part.TupleSize())
+			for part.Next(values) {
+				results[i] = append(results[i], asString(values))
+			}
+		}()
+	}
+	wg.Wait()
+	var actual []string
+	for _, result := range results {
+		actual = append(actual, result...)
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestTStreamShuffle(t *testing.T) {
+	stream := gocombinatorics.TCombinations([]string{"a", "b", "c", "d"}, 2)
+	shuffled := stream.Shuffle(5)
+	var actual []string
+	values := make([]string, 2)
+	for shuffled.Next(values) {
+		actual = append(actual, values[0]+values[1])
+	}
+	assert.ElementsMatch(t,
+		[]string{"ab", "ac", "ad", "bc", "bd", "cd"}, actual)
+
+	// Shuffle does not change stream
+	assertTStream(t, stream, "a b", "a c", "a d", "b c", "b d", "c d")
+
+	var zero gocombinatorics.TStream[string]
+	assertTStream(t, zero.Shuffle(5))
+}
@@ -0,0 +1,171 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// splittableStream is a rankedStream that can be copied.
+type splittableStream interface {
+	rankedStream
+
+	// position returns the index of the tuple that Next yields next or
+	// the number of tuples if Next would return false.
+	position() *big.Int
+
+	// clone returns an independent copy of this stream including its
+	// current position.
+	clone() splittableStream
+}
+
+// Split divides the remaining tuples of stream into parts contiguous
+// Streams that have nearly the same number of tuples. The tuples of the
+// first returned Stream come first; the tuples of the last returned Stream
+// come last. Each returned Stream is independent of the others and of
+// stream, so they can be used in separate goroutines. Reset on a returned
+// Stream goes back to the first tuple of that Stream. Split does not
+// change stream.
+//
+// Only the streams in this package that implement Unranker, Ranker, and
+// Seeker support splitting, along with the Streams that Range, Split, and
+// Shuffle return from them. Streams from outside this package do not.
+// Split panics if stream does not support splitting or if parts is less
+// than 1.
+func Split(stream Stream, parts int) []Stream {
+	if parts < 1 {
+		panic("parts must be at least 1")
+	}
+	s := mustSupport[splittableStream](stream, "Split")
+	start := s.position()
+	remaining := new(big.Int).Sub(s.Count(), start)
+	bigParts := big.NewInt(int64(parts))
+	result := make([]Stream, parts)
+	prevEnd := start
+	for i := range result {
+		end := big.NewInt(int64(i + 1))
+		end.Mul(end, remaining)
+		end.Quo(end, bigParts)
+		end.Add(end, start)
+		result[i] = RangeBig(s.clone(), prevEnd, end)
+		prevEnd = end
+	}
+	return result
+}
+
+// Split divides the remaining tuples of this TStream into parts contiguous
+// TStreams that have nearly the same number of tuples. Each returned
+// TStream is independent of the others and of this TStream, so they can be
+// used in separate goroutines. Reset on a returned TStream goes back to the
+// first tuple of that TStream. Split does not change this TStream. Split
+// panics if this TStream does not support splitting or if parts is less
+// than 1.
+func (t *TStream[T]) Split(parts int) []*TStream[T] {
+	if parts < 1 {
+		panic("parts must be at least 1")
+	}
+	result := make([]*TStream[T], parts)
+	if t.stream == nil {
+		for i := range result {
+			result[i] = &TStream[T]{}
+		}
+		return result
+	}
+	for i, stream := range Split(t.stream, parts) {
+		result[i] = &TStream[T]{
+			items:   t.items,
+			indexes: make([]int, len(t.indexes)),
+			stream:  stream,
+		}
+	}
+	return result
+}
+
+func (c *combinations) position() *big.Int {
+	return position(c, c.done, c.values)
+}
+
+func (c *combinations) clone() splittableStream {
+	result := *c
+	result.values = append([]int(nil), c.values...)
+	return &result
+}
+
+func (c *combinationsWithReplacement) position() *big.Int {
+	return position(c, c.done, c.values)
+}
+
+func (c *combinationsWithReplacement) clone() splittableStream {
+	result := *c
+	result.values = append([]int(nil), c.values...)
+	return &result
+}
+
+func (o *opsPosits) position() *big.Int {
+	return position(o, o.done, o.values)
+}
+
+func (o *opsPosits) clone() splittableStream {
+	result := *o
+	result.values = append([]int(nil), o.values...)
+	return &result
+}
+
+func (p *permutations) position() *big.Int {
+	return position(p, p.done, p.values)
+}
+
+func (p *permutations) clone() splittableStream {
+	result := *p
+	result.values = append([]int(nil), p.values...)
+	result.unused = append(intSet(nil), p.unused...)
+	return &result
+}
+
+func (c *cartesian) position() *big.Int {
+	return position(c, c.done, c.values)
+}
+
+func (c *cartesian) clone() splittableStream {
+	result := *c
+	result.values = append([]int(nil), c.values...)
+	return &result
+}
+
+func (p *product) position() *big.Int {
+	return position(p, p.done, p.values)
+}
+
+func (p *product) clone() splittableStream {
+	result := *p
+	result.values = append([]int(nil), p.values...)
+	return &result
+}
+
+func (r *rangeStream) position() *big.Int {
+	return new(big.Int).Sub(r.count, r.remaining)
+}
+
+func (r *rangeStream) clone() splittableStream {
+	result := *r
+	result.stream = r.stream.(splittableStream).clone()
+	result.remaining = new(big.Int).Set(r.remaining)
+	return &result
+}
+
+// position returns the index of values within stream or the number of
+// tuples in stream if done is true.
+func position(
+	stream interface {
+		Counter
+		Ranker
+	},
+	done bool,
+	values []int) *big.Int {
+	if done {
+		return stream.Count()
+	}
+	result, err := stream.RankBig(values)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
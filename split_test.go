@@ -0,0 +1,115 @@
+package gocombinatorics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	for parts := 1; parts < 8; parts++ {
+		assertSplit(t, gocombinatorics.Combinations(5, 3), parts)
+		assertSplit(t, gocombinatorics.CombinationsWithReplacement(3, 3), parts)
+		assertSplit(t, gocombinatorics.Permutations(4, 3), parts)
+		assertSplit(t, gocombinatorics.Product(3, 2), parts)
+		assertSplit(t, gocombinatorics.Cartesian(2, 3), parts)
+		assertSplit(t, gocombinatorics.OpsPosits(4), parts)
+		assertSplit(t, gocombinatorics.Combinations(3, 4), parts)
+		assertSplit(
+			t,
+			gocombinatorics.Range(gocombinatorics.Permutations(4, 4), 5, 17),
+			parts)
+	}
+}
+
+func TestSplitRemaining(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.Permutations(4, 2)
+	assertNext(t, stream, "0 1", "0 2", "0 3")
+	parts := gocombinatorics.Split(stream, 2)
+	assertStream(t, parts[0], "1 0", "1 2", "1 3", "2 0")
+	assertStream(t, parts[1], "2 1", "2 3", "3 0", "3 1", "3 2")
+
+	// stream itself is unchanged
+	assertNext(t, stream, "1 0")
+	assert.Panics(func() { gocombinatorics.Split(stream, 0) })
+}
+
+func TestSplitOutsideStream(t *testing.T) {
+	assert := assert.New(t)
+	stream := newOutsideStream(gocombinatorics.Combinations(5, 2))
+	assert.PanicsWithValue("This stream does not support Split.", func() {
+		gocombinatorics.Split(stream, 2)
+	})
+	ranged := gocombinatorics.Range(stream, 2, 7)
+	assertStream(t, ranged, "0 3", "0 4", "1 2", "1 3", "1 4")
+	assert.PanicsWithValue("This stream does not support Split.", func() {
+		gocombinatorics.Split(ranged, 2)
+	})
+}
+
+// outsideStream is a stream from outside this package that supports
+// Counter, Unranker, Ranker, and Seeker.
+type outsideStream struct {
+	gocombinatorics.Stream
+	gocombinatorics.Counter
+	gocombinatorics.Unranker
+	gocombinatorics.Ranker
+	gocombinatorics.Seeker
+}
+
+func newOutsideStream(stream gocombinatorics.Stream) *outsideStream {
+	return &outsideStream{
+		Stream:   stream,
+		Counter:  stream.(gocombinatorics.Counter),
+		Unranker: stream.(gocombinatorics.Unranker),
+		Ranker:   stream.(gocombinatorics.Ranker),
+		Seeker:   stream.(gocombinatorics.Seeker),
+	}
+}
+
+func TestTStreamSplit(t *testing.T) {
+	assert := assert.New(t)
+	stream := gocombinatorics.TCombinations(
+		[]string{"red", "orange", "yellow", "green", "blue"}, 3)
+	parts := stream.Split(3)
+	assert.Len(parts, 3)
+	assertTStream(t, parts[0],
+		"red orange yellow", "red orange green", "red orange blue")
+	assertTStream(t, parts[1],
+		"red yellow green", "red yellow blue", "red green blue")
+	assertTStream(t, parts[2],
+		"orange yellow green", "orange yellow blue", "orange green blue",
+		"yellow green blue")
+	assert.Panics(func() { stream.Split(0) })
+	var zero gocombinatorics.TStream[string]
+	for _, part := range zero.Split(2) {
+		assertTStream(t, part)
+	}
+}
+
+// assertSplit asserts that the parts from splitting stream have
+// nearly the same size and together yield the tuples of stream in order.
+func assertSplit(t *testing.T, stream gocombinatorics.Stream, parts int) {
+	t.Helper()
+	assert := assert.New(t)
+	expected := allTuples(stream)
+	stream.Reset()
+	var actual []string
+	minSize, maxSize := len(expected), 0
+	for _, part := range gocombinatorics.Split(stream, parts) {
+		assert.Equal(stream.TupleSize(), part.TupleSize())
+		tuples := allTuples(part)
+		if len(tuples) < minSize {
+			minSize = len(tuples)
+		}
+		if len(tuples) > maxSize {
+			maxSize = len(tuples)
+		}
+		actual = append(actual, tuples...)
+	}
+	assert.Equal(strings.Join(expected, ","), strings.Join(actual, ","))
+	assert.LessOrEqual(maxSize-minSize, 1)
+}
@@ -1,5 +1,9 @@
 package gocombinatorics
 
+import (
+	"fmt"
+)
+
 // TStream is like Stream but it emits tuples of type T. The zero value
 // emits no tuples. Copying a TStream is not supported and may lead to
 // errors.
@@ -35,11 +39,16 @@ func TProduct[T any](items []T, k int) *TStream[T] {
 
 func newTStream[T any](
 	items []T, k int, streamType func(n, k int) Stream) *TStream[T] {
-	stream := streamType(len(items), k)
-	indexes := make([]int, stream.TupleSize())
+	return newTStreamFrom(
+		append([]T(nil), items...), streamType(len(items), k))
+}
+
+// newTStreamFrom returns a TStream that emits the items at the indexes
+// that stream yields. newTStreamFrom takes ownership of items.
+func newTStreamFrom[T any](items []T, stream Stream) *TStream[T] {
 	return &TStream[T]{
-		items:   append([]T(nil), items...),
-		indexes: indexes,
+		items:   items,
+		indexes: make([]int, stream.TupleSize()),
 		stream:  stream,
 	}
 }
@@ -73,3 +82,13 @@ func (t *TStream[T]) Reset() {
 		t.stream.Reset()
 	}
 }
+
+// mustSupport returns stream as an I. If stream is not an I, mustSupport
+// panics with a message saying that stream does not support method.
+func mustSupport[I any](stream Stream, method string) I {
+	result, ok := stream.(I)
+	if !ok {
+		panic(fmt.Sprintf("This stream does not support %s.", method))
+	}
+	return result
+}
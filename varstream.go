@@ -0,0 +1,244 @@
+package gocombinatorics
+
+import (
+	"math/big"
+)
+
+// VarStream represents a finite stream of tuples that may differ in size.
+type VarStream interface {
+
+	// NextN populates values with the next tuple and returns the size of
+	// that tuple and true. If there are no more tuples, NextN returns 0 and
+	// false and leaves values unchanged. Caller must pass in a slice big
+	// enough to hold the largest tuple.
+	NextN(values []int) (int, bool)
+
+	// MaxTupleSize returns the size of the largest tuple this stream can
+	// emit. Caller must pass a slice of at least this size to the NextN
+	// method.
+	MaxTupleSize() int
+
+	// Reset resets this stream to the state it had when it was first created.
+	// After calling Reset, NextN will yield the first tuple.
+	Reset()
+}
+
+// TVarStream is like VarStream but it emits tuples of type T. The zero
+// value emits no tuples. Copying a TVarStream is not supported and may
+// lead to errors.
+type TVarStream[T any] struct {
+	items   []T
+	indexes []int
+	stream  VarStream
+}
+
+// newTVarStreamFrom returns a TVarStream that emits the items at the
+// indexes that stream yields. newTVarStreamFrom takes ownership of items.
+func newTVarStreamFrom[T any](items []T, stream VarStream) *TVarStream[T] {
+	return &TVarStream[T]{
+		items:   items,
+		indexes: make([]int, stream.MaxTupleSize()),
+		stream:  stream,
+	}
+}
+
+// NextN populates values with the next tuple and returns the size of that
+// tuple and true. If there are no more tuples, NextN returns 0 and false
+// and leaves values unchanged. Caller must pass in a slice big enough to
+// hold the largest tuple.
+func (t *TVarStream[T]) NextN(values []T) (int, bool) {
+	if len(values) < len(t.indexes) {
+		panic(kSliceTooSmall)
+	}
+	if t.stream == nil {
+		return 0, false
+	}
+	n, ok := t.stream.NextN(t.indexes)
+	if !ok {
+		return 0, false
+	}
+	for i := 0; i < n; i++ {
+		values[i] = t.items[t.indexes[i]]
+	}
+	return n, true
+}
+
+// MaxTupleSize returns the size of the largest tuple this TVarStream can
+// emit. Caller must pass a slice of at least this size to the NextN
+// method.
+func (t *TVarStream[T]) MaxTupleSize() int {
+	return len(t.indexes)
+}
+
+// Reset resets this TVarStream to the state it had when it was first
+// created. After calling Reset, NextN will yield the first tuple.
+func (t *TVarStream[T]) Reset() {
+	if t.stream != nil {
+		t.stream.Reset()
+	}
+}
+
+// Count returns the total number of tuples this TVarStream emits from
+// start to finish. Count is unaffected by calls to NextN and Reset. If
+// this TVarStream cannot count its tuples without going through them,
+// Count returns nil.
+func (t *TVarStream[T]) Count() *big.Int {
+	if t.stream == nil {
+		return new(big.Int)
+	}
+	counter, ok := t.stream.(Counter)
+	if !ok {
+		return nil
+	}
+	return counter.Count()
+}
+
+// Count64 is like Count except that it returns the count as an int64.
+// If the count does not fit in an int64 or if Count returns nil, Count64
+// returns false.
+func (t *TVarStream[T]) Count64() (int64, bool) {
+	return count64(t)
+}
+
+// AsVarStream returns a VarStream that yields the same tuples as stream.
+// The returned VarStream implements Counter if stream does. The returned
+// VarStream takes ownership of stream.
+func AsVarStream(stream Stream) VarStream {
+	return withVarCount(
+		&fixedVarStream{stream: stream}, []Stream{stream}, sumCounts)
+}
+
+// Pad returns a Stream that yields the same tuples as stream except that
+// it pads each tuple with fill to make it stream.MaxTupleSize() long.
+// The returned Stream implements Counter if stream does. The returned
+// Stream takes ownership of stream.
+func Pad(stream VarStream, fill int) Stream {
+	return withCount(
+		&paddedStream{stream: stream, fill: fill},
+		[]VarStream{stream},
+		sumCounts)
+}
+
+// TrimTrailing returns a VarStream that yields the same tuples as stream
+// except that it removes the values equal to value from the end of each
+// tuple. For instance, TrimTrailing(IntegerPartitions(n), 0) yields the
+// partitions of n without the padding zeros. The returned VarStream
+// implements Counter if stream does. The returned VarStream takes
+// ownership of stream.
+func TrimTrailing(stream Stream, value int) VarStream {
+	return withVarCount(
+		&trimmedStream{
+			stream: stream,
+			trim:   func(x int) bool { return x == value },
+		},
+		[]Stream{stream},
+		sumCounts)
+}
+
+// AsVarStream returns a TVarStream that yields the same tuples as this
+// TStream. The returned TVarStream takes ownership of this TStream.
+func (t *TStream[T]) AsVarStream() *TVarStream[T] {
+	if t.stream == nil {
+		return &TVarStream[T]{}
+	}
+	return newTVarStreamFrom(t.items, AsVarStream(t.stream))
+}
+
+// Pad returns a TStream that yields the same tuples as this TVarStream
+// except that it pads each tuple with fill to make it t.MaxTupleSize()
+// long. The returned TStream takes ownership of this TVarStream.
+func (t *TVarStream[T]) Pad(fill T) *TStream[T] {
+	if t.stream == nil {
+		return &TStream[T]{}
+	}
+
+	// The index of fill is just past the end of the existing items.
+	items := append(t.items[:len(t.items):len(t.items)], fill)
+	return newTStreamFrom(items, Pad(t.stream, len(t.items)))
+}
+
+// TTrimTrailing returns a TVarStream that yields the same tuples as
+// stream except that it removes the items equal to value from the end of
+// each tuple. The returned TVarStream takes ownership of stream.
+func TTrimTrailing[T comparable](
+	stream *TStream[T], value T) *TVarStream[T] {
+	if stream.stream == nil {
+		return &TVarStream[T]{}
+	}
+	items := stream.items
+	return newTVarStreamFrom(items, withVarCount(
+		&trimmedStream{
+			stream: stream.stream,
+			trim:   func(x int) bool { return items[x] == value },
+		},
+		[]Stream{stream.stream},
+		sumCounts))
+}
+
+type fixedVarStream struct {
+	stream Stream
+}
+
+func (f *fixedVarStream) MaxTupleSize() int {
+	return f.stream.TupleSize()
+}
+
+func (f *fixedVarStream) NextN(values []int) (int, bool) {
+	if !f.stream.Next(values) {
+		return 0, false
+	}
+	return f.stream.TupleSize(), true
+}
+
+func (f *fixedVarStream) Reset() {
+	f.stream.Reset()
+}
+
+type paddedStream struct {
+	stream VarStream
+	fill   int
+}
+
+func (p *paddedStream) TupleSize() int {
+	return p.stream.MaxTupleSize()
+}
+
+func (p *paddedStream) Next(values []int) bool {
+	n, ok := p.stream.NextN(values)
+	if !ok {
+		return false
+	}
+	size := p.stream.MaxTupleSize()
+	for i := n; i < size; i++ {
+		values[i] = p.fill
+	}
+	return true
+}
+
+func (p *paddedStream) Reset() {
+	p.stream.Reset()
+}
+
+type trimmedStream struct {
+	stream Stream
+	trim   func(x int) bool
+}
+
+func (t *trimmedStream) MaxTupleSize() int {
+	return t.stream.TupleSize()
+}
+
+func (t *trimmedStream) NextN(values []int) (int, bool) {
+	if !t.stream.Next(values) {
+		return 0, false
+	}
+	n := t.stream.TupleSize()
+	for n > 0 && t.trim(values[n-1]) {
+		n--
+	}
+	return n, true
+}
+
+func (t *trimmedStream) Reset() {
+	t.stream.Reset()
+}
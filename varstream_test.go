@@ -0,0 +1,139 @@
+package gocombinatorics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep94/gocombinatorics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroTVarStream(t *testing.T) {
+	var stream gocombinatorics.TVarStream[string]
+	assert.Zero(t, stream.MaxTupleSize())
+	_, ok := stream.NextN(nil)
+	assert.False(t, ok)
+	stream.Reset()
+}
+
+// Reads first tuple off stream, resets it, then reads first 2 tuples off
+// stream, resets again, then reads first 3 tuples off stream etc. until
+// all expected tuples are read off stream.
+func assertVarStream(
+	t *testing.T,
+	stream gocombinatorics.VarStream,
+	results ...string) {
+	t.Helper()
+	assert := assert.New(t)
+	values := make([]int, stream.MaxTupleSize())
+	for i := 0; i <= len(results)+1; i++ {
+		for j := 0; j <= i; j++ {
+			n, hasMore := stream.NextN(values)
+			if j >= len(results) {
+				if !assert.False(hasMore, "There shouldn't be more tuples") {
+					return
+				}
+			} else {
+				if !assert.True(hasMore, "There should be more tuples") {
+					return
+				}
+				valueStr := asString(values[:n])
+				makeZero(values)
+				if !assert.Equal(results[j], valueStr) {
+					return
+				}
+			}
+		}
+		stream.Reset()
+	}
+}
+
+// Like assertVarStream but for TVarStream.
+func assertTVarStream(
+	t *testing.T,
+	stream *gocombinatorics.TVarStream[string],
+	results ...string) {
+	t.Helper()
+	assert := assert.New(t)
+	values := make([]string, stream.MaxTupleSize())
+	for i := 0; i <= len(results)+1; i++ {
+		for j := 0; j <= i; j++ {
+			n, hasMore := stream.NextN(values)
+			if j >= len(results) {
+				if !assert.False(hasMore, "There shouldn't be more tuples") {
+					return
+				}
+			} else {
+				if !assert.True(hasMore, "There should be more tuples") {
+					return
+				}
+				valueStr := strings.Join(values[:n], " ")
+				makeEmpty(values)
+				if !assert.Equal(results[j], valueStr) {
+					return
+				}
+			}
+		}
+		stream.Reset()
+	}
+}
+
+func TestAsVarStream(t *testing.T) {
+	stream := gocombinatorics.AsVarStream(gocombinatorics.Combinations(3, 2))
+	assert.Equal(t, 2, stream.MaxTupleSize())
+	assert.Panics(t, func() { stream.NextN(nil) })
+	assertVarStream(t, stream, "0 1", "0 2", "1 2")
+}
+
+func TestPad(t *testing.T) {
+	stream := gocombinatorics.Pad(gocombinatorics.PowerSet(2), -1)
+	assert.Equal(t, 2, stream.TupleSize())
+	assert.Panics(t, func() { stream.Next(nil) })
+	assertStream(t, stream, "-1 -1", "0 -1", "1 -1", "0 1")
+	assertCount(t, stream)
+	assertCount(t, gocombinatorics.Pad(
+		gocombinatorics.AsVarStream(gocombinatorics.Combinations(4, 2)), -1))
+	assertCount(t, gocombinatorics.Pad(
+		gocombinatorics.TrimTrailing(gocombinatorics.Product(2, 2), 1), -1))
+}
+
+func TestTrimTrailing(t *testing.T) {
+	stream := gocombinatorics.TrimTrailing(
+		gocombinatorics.IntegerPartitions(4), 0)
+	assert.Equal(t, 4, stream.MaxTupleSize())
+	assert.Panics(t, func() { stream.NextN(nil) })
+	assertVarStream(t, stream, "1 1 1 1", "2 1 1", "2 2", "3 1", "4")
+	stream = gocombinatorics.TrimTrailing(
+		gocombinatorics.Product(2, 2), 1)
+	assertVarStream(t, stream, "0 0", "0", "1 0", "")
+}
+
+func TestTStreamAsVarStream(t *testing.T) {
+	stream := gocombinatorics.TCombinations([]string{"a", "b", "c"}, 2)
+	assertTVarStream(t, stream.AsVarStream(), "a b", "a c", "b c")
+	var zero gocombinatorics.TStream[string]
+	assertTVarStream(t, zero.AsVarStream())
+}
+
+func TestTVarStreamPad(t *testing.T) {
+	stream := gocombinatorics.TPowerSet([]string{"a", "b"}).Pad("-")
+	assert.Equal(t, 2, stream.TupleSize())
+	assertTStream(t, stream, "- -", "a -", "b -", "a b")
+	assert.Equal(t, "4", stream.Count().String())
+	powerSet := gocombinatorics.TPowerSet([]string{"a", "b", "c"})
+	assert.Equal(t, "8", powerSet.Count().String())
+	count, ok := powerSet.Count64()
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), count)
+	var zero gocombinatorics.TVarStream[string]
+	assertTStream(t, zero.Pad("-"))
+	assert.Equal(t, "0", zero.Count().String())
+}
+
+func TestTTrimTrailing(t *testing.T) {
+	stream := gocombinatorics.TTrimTrailing(
+		gocombinatorics.TProduct([]string{"x", ""}, 2), "")
+	assertTVarStream(t, stream, "x x", "x", " x", "")
+	var zero gocombinatorics.TStream[string]
+	assertTVarStream(t, gocombinatorics.TTrimTrailing(&zero, ""))
+}